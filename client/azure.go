@@ -0,0 +1,100 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// AzureConfig configures the client to target an Azure OpenAI resource
+type AzureConfig struct {
+	// Endpoint is the resource endpoint, e.g. "https://my-resource.openai.azure.com"
+	Endpoint string
+	// APIVersion is sent as the api-version query parameter. It may be empty for the v1 API.
+	APIVersion string
+	// Deployment routes requests to /openai/deployments/{Deployment} when set
+	Deployment string
+	// V1 routes requests to the /openai/v1 API instead of /openai
+	V1 bool
+	// TokenProvider returns Microsoft Entra ID bearer tokens. When set, tokens are used
+	// instead of the api-key header.
+	TokenProvider func(ctx context.Context) (string, error)
+}
+
+// AzureOption is a function that configures an AzureConfig
+type AzureOption func(*AzureConfig)
+
+// WithAzureDeployment routes requests to the given deployment
+func WithAzureDeployment(deployment string) AzureOption {
+	return func(a *AzureConfig) {
+		a.Deployment = deployment
+	}
+}
+
+// WithAzureV1 routes requests to the Azure OpenAI v1 API under /openai/v1
+func WithAzureV1() AzureOption {
+	return func(a *AzureConfig) {
+		a.V1 = true
+	}
+}
+
+// WithAzureTokenProvider authenticates with Microsoft Entra ID bearer tokens
+func WithAzureTokenProvider(provider func(ctx context.Context) (string, error)) AzureOption {
+	return func(a *AzureConfig) {
+		a.TokenProvider = provider
+	}
+}
+
+// WithAzure configures the client to target Azure OpenAI. Request URLs are rewritten for the
+// resource endpoint, the api-version query parameter is added, and the API key is sent in the
+// api-key header. If no API key is set, it is read from the AZURE_OPENAI_API_KEY environment variable.
+func WithAzure(endpoint, apiVersion string, options ...AzureOption) ClientOption {
+	return func(c *Client) {
+		azure := &AzureConfig{
+			Endpoint:   strings.TrimRight(endpoint, "/"),
+			APIVersion: apiVersion,
+		}
+		for _, option := range options {
+			option(azure)
+		}
+		c.azure = azure
+	}
+}
+
+// url builds the Azure URL for the given API path against the resource endpoint, which is a.Endpoint
+// or the base URL of one of the endpoints configured with WithEndpoints
+func (a *AzureConfig) url(endpoint, path string) (*url.URL, error) {
+	base := strings.TrimRight(endpoint, "/") + "/openai"
+	switch {
+	case a.Deployment != "":
+		base += "/deployments/" + url.PathEscape(a.Deployment)
+	case a.V1:
+		base += "/v1"
+	}
+
+	u, err := url.Parse(base + path)
+	if err != nil {
+		return nil, err
+	}
+	if a.APIVersion != "" {
+		query := u.Query()
+		query.Set("api-version", a.APIVersion)
+		u.RawQuery = query.Encode()
+	}
+	return u, nil
+}
+
+// authorize sets the Azure authentication header using either an Entra ID token or the API key
+func (a *AzureConfig) authorize(ctx context.Context, header http.Header, apiKey string) error {
+	if a.TokenProvider != nil {
+		token, err := a.TokenProvider(ctx)
+		if err != nil {
+			return err
+		}
+		header.Set("Authorization", "Bearer "+token)
+		return nil
+	}
+	header.Set("api-key", apiKey)
+	return nil
+}
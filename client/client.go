@@ -1,14 +1,16 @@
 package client
 
 import (
-	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
-	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"os"
+	"slices"
 	"time"
 )
 
@@ -17,22 +19,74 @@ const (
 	DefaultBaseURL = "https://api.openai.com/v1"
 	// DefaultUserAgent is the default user agent for the OpenAI API client
 	DefaultUserAgent = "openai-responses-api-go/1.0.0"
-	// DefaultTimeout is the default timeout for API requests
+	// DefaultTimeout is the default timeout for non-streaming API requests
 	DefaultTimeout = 30 * time.Second
 )
 
-// Client is the client for the OpenAI Responses API
+// Client is the client for the OpenAI Responses API. Its configuration is set by the options passed
+// to NewClient and cannot be changed afterwards, so a Client is safe for concurrent use.
+// Use With to derive a client with a different configuration.
 type Client struct {
-	// BaseURL is the base URL for API requests
-	BaseURL string
-	// APIKey is the API key for authentication
-	APIKey string
-	// HTTPClient is the HTTP client used to make API requests
-	HTTPClient *http.Client
-	// UserAgent is the user agent for API requests
-	UserAgent string
-	// Organization is the organization ID for API requests
-	Organization string
+	// baseURL is the base URL for API requests
+	baseURL string
+	// apiKey is the API key for authentication
+	apiKey string
+	// httpClient is the HTTP client used to make API requests
+	httpClient *http.Client
+	// userAgent is the user agent for API requests
+	userAgent string
+	// organization is the organization ID for API requests
+	organization string
+	// project is the project ID for API requests
+	project string
+	// tenants selects organization, project and key per call when set
+	tenants *TenantRegistry
+	// retry controls how failed requests are retried
+	retry RetryPolicy
+	// rateLimiter optionally throttles outgoing requests on the client side
+	rateLimiter *RateLimiter
+	// concurrencyLimiter optionally bounds the number of concurrent calls and queues the rest by priority
+	concurrencyLimiter *ConcurrencyLimiter
+	// middleware is the chain of middleware every API call passes through
+	middleware []Middleware
+	// azure configures the client for Azure OpenAI when set
+	azure *AzureConfig
+	// credentials supplies the API key for every request when set, taking precedence over apiKey
+	credentials CredentialProvider
+	// timeout is the total timeout for non-streaming requests
+	timeout time.Duration
+	// streamTimeouts are the timeouts enforced while streaming a response
+	streamTimeouts StreamTimeouts
+	// endpoints are the endpoints to fail over between. When set, they replace baseURL.
+	endpoints []Endpoint
+	// circuitBreaker controls when a failing endpoint is taken out of rotation
+	circuitBreaker CircuitBreakerConfig
+	// logger receives structured logs of every call when set
+	logger *slog.Logger
+	// logConfig controls what is logged and at which levels
+	logConfig LogConfig
+	// tracer receives spans for every Create and CreateStream call when set
+	tracer Tracer
+	// meter receives metrics for every Create and CreateStream call when set
+	meter Meter
+
+	// proxy is the proxy all requests are routed through when set
+	proxy *url.URL
+	// rootCAs are the certificate authorities used to verify the server when set
+	rootCAs *x509.CertPool
+	// certificates are presented to the server for mutual TLS
+	certificates []tls.Certificate
+	// signer signs every outgoing request when set
+	signer RequestSigner
+	// har records all HTTP traffic when set
+	har *HARRecorder
+
+	// pool tracks the health of the endpoints
+	pool *endpointPool
+	// lifecycle tracks the calls in flight for graceful shutdown
+	lifecycle *lifecycle
+	// transportChanged is set by options that require the HTTP transport to be reconfigured
+	transportChanged bool
 }
 
 // ClientOption is a function that configures a Client
@@ -41,44 +95,59 @@ type ClientOption func(*Client)
 // WithBaseURL sets the base URL for the client
 func WithBaseURL(baseURL string) ClientOption {
 	return func(c *Client) {
-		c.BaseURL = baseURL
+		c.baseURL = baseURL
 	}
 }
 
 // WithAPIKey sets the API key for the client
 func WithAPIKey(apiKey string) ClientOption {
 	return func(c *Client) {
-		c.APIKey = apiKey
+		c.apiKey = apiKey
 	}
 }
 
 // WithHTTPClient sets the HTTP client for the client
 func WithHTTPClient(httpClient *http.Client) ClientOption {
 	return func(c *Client) {
-		c.HTTPClient = httpClient
+		c.httpClient = httpClient
+		c.transportChanged = true
 	}
 }
 
 // WithUserAgent sets the user agent for the client
 func WithUserAgent(userAgent string) ClientOption {
 	return func(c *Client) {
-		c.UserAgent = userAgent
+		c.userAgent = userAgent
 	}
 }
 
 // WithOrganization sets the organization ID for the client
 func WithOrganization(organization string) ClientOption {
 	return func(c *Client) {
-		c.Organization = organization
+		c.organization = organization
+	}
+}
+
+// WithProject sets the project ID for the client
+func WithProject(project string) ClientOption {
+	return func(c *Client) {
+		c.project = project
 	}
 }
 
 // NewClient creates a new OpenAI Responses API client
 func NewClient(options ...ClientOption) *Client {
+	// The HTTP client has no overall timeout so that long running streams are not cut off,
+	// timeouts are enforced per call instead
 	client := &Client{
-		BaseURL:    DefaultBaseURL,
-		UserAgent:  DefaultUserAgent,
-		HTTPClient: &http.Client{Timeout: DefaultTimeout},
+		baseURL:        DefaultBaseURL,
+		userAgent:      DefaultUserAgent,
+		httpClient:     &http.Client{},
+		timeout:        DefaultTimeout,
+		streamTimeouts: DefaultStreamTimeouts(),
+		circuitBreaker: DefaultCircuitBreakerConfig(),
+		logConfig:      DefaultLogConfig(),
+		lifecycle:      newLifecycle(),
 	}
 
 	// Apply options
@@ -87,13 +156,71 @@ func NewClient(options ...ClientOption) *Client {
 	}
 
 	// If API key is not set, try to get it from environment variable
-	if client.APIKey == "" {
-		client.APIKey = os.Getenv("OPENAI_API_KEY")
+	if client.apiKey == "" {
+		if client.azure != nil {
+			client.apiKey = os.Getenv("AZURE_OPENAI_API_KEY")
+		} else {
+			client.apiKey = os.Getenv("OPENAI_API_KEY")
+		}
+	}
+
+	// Apply the proxy and TLS settings
+	client.configureTransport()
+
+	// Track the health of the endpoints if any are configured
+	if len(client.endpoints) > 0 {
+		client.pool = newEndpointPool(client.endpoints, client.circuitBreaker)
 	}
 
 	return client
 }
 
+// With returns a new client that shares the configuration, HTTP client, connection pool, limiters and
+// endpoint health of c, with the given options applied on top. Changing the HTTP client, proxy or TLS
+// settings gives the derived client its own connection pool. Deriving a client is cheap, so a client
+// per organization, project or key can be derived on demand. c itself is not modified.
+func (c *Client) With(options ...ClientOption) *Client {
+	derived := *c
+	derived.middleware = slices.Clip(c.middleware)
+	derived.endpoints = slices.Clip(c.endpoints)
+
+	// Apply options
+	for _, option := range options {
+		option(&derived)
+	}
+	derived.configureTransport()
+
+	// Keep tracking endpoint health together unless the endpoints or the circuit breaker changed
+	if !sameEndpoints(derived.endpoints, c.endpoints) || derived.circuitBreaker != c.circuitBreaker {
+		derived.pool = nil
+		if len(derived.endpoints) > 0 {
+			derived.pool = newEndpointPool(derived.endpoints, derived.circuitBreaker)
+		}
+	}
+
+	return &derived
+}
+
+// BaseURL returns the base URL for API requests
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// Organization returns the organization ID sent with API requests
+func (c *Client) Organization() string {
+	return c.organization
+}
+
+// Project returns the project ID sent with API requests
+func (c *Client) Project() string {
+	return c.project
+}
+
+// HTTPClient returns the HTTP client used to make API requests
+func (c *Client) HTTPClient() *http.Client {
+	return c.httpClient
+}
+
 // APIError represents an error returned by the OpenAI API
 type APIError struct {
 	Code       *string `json:"code,omitempty"`
@@ -101,16 +228,32 @@ type APIError struct {
 	Param      *string `json:"param,omitempty"`
 	Type       string  `json:"type"`
 	StatusCode int     `json:"-"`
+	RequestID  string  `json:"-"`
+	// RawBody holds the response body when it could not be decoded as a JSON error
+	RawBody string `json:"-"`
 }
 
 // Error implements the error interface
 func (e *APIError) Error() string {
+	var requestID string
+	if e.RequestID != "" {
+		requestID = " request_id=" + e.RequestID
+	}
 	if e.Code != nil {
-		return fmt.Sprintf("OpenAI API error: code=%s message=%s param=%v type=%s status_code=%d",
-			*e.Code, e.Message, e.Param, e.Type, e.StatusCode)
+		return fmt.Sprintf("OpenAI API error: code=%s message=%s param=%v type=%s status_code=%d%s",
+			*e.Code, e.Message, e.Param, e.Type, e.StatusCode, requestID)
+	}
+	return fmt.Sprintf("OpenAI API error: message=%s param=%v type=%s status_code=%d%s",
+		e.Message, e.Param, e.Type, e.StatusCode, requestID)
+}
+
+// Retryable reports whether the request may succeed when retried
+func (e *APIError) Retryable() bool {
+	switch e.StatusCode {
+	case http.StatusRequestTimeout, http.StatusConflict, http.StatusTooManyRequests:
+		return true
 	}
-	return fmt.Sprintf("OpenAI API error: message=%s param=%v type=%s status_code=%d",
-		e.Message, e.Param, e.Type, e.StatusCode)
+	return e.StatusCode >= 500
 }
 
 // ErrorResponse represents the error response from the OpenAI API
@@ -118,80 +261,39 @@ type ErrorResponse struct {
 	Error *APIError `json:"error,omitempty"`
 }
 
-// request makes an HTTP request to the OpenAI API
-func (c *Client) request(ctx context.Context, method, path string, body interface{}, v interface{}) error {
-	// Construct the URL
-	u, err := url.Parse(c.BaseURL + path)
+// request makes an HTTP request to the OpenAI API and decodes the JSON response into v
+func (c *Client) request(ctx context.Context, method, path string, body interface{}, v interface{}, options ...RequestOption) (*Response, error) {
+	resp, err := c.send(ctx, &Request{
+		Method: method,
+		Path:   path,
+		Body:   body,
+	}, options...)
 	if err != nil {
-		return err
-	}
-
-	// Create the request body
-	var reqBody io.Reader
-	if body != nil {
-		jsonBody, err := json.Marshal(body)
-		if err != nil {
-			return err
-		}
-		reqBody = bytes.NewBuffer(jsonBody)
-	}
-
-	// Create the request
-	req, err := http.NewRequestWithContext(ctx, method, u.String(), reqBody)
-	if err != nil {
-		return err
-	}
-
-	// Set headers
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("User-Agent", c.UserAgent)
-	req.Header.Set("Authorization", "Bearer "+c.APIKey)
-	if c.Organization != "" {
-		req.Header.Set("OpenAI-Organization", c.Organization)
-	}
-
-	// Make the request
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
-	// Check for errors
-	if resp.StatusCode >= 400 {
-		var errResp ErrorResponse
-		if err := json.NewDecoder(resp.Body).Decode(&errResp); err != nil {
-			return fmt.Errorf("error decoding error response: %w", err)
-		}
-		if errResp.Error != nil {
-			errResp.Error.StatusCode = resp.StatusCode
-			return errResp.Error
-		}
-		return fmt.Errorf("unknown error, status code: %d", resp.StatusCode)
+		return nil, err
 	}
+	defer resp.HTTP.Body.Close()
 
 	// Decode the response
 	if v != nil {
-		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
-			return err
+		if err := json.NewDecoder(resp.HTTP.Body).Decode(v); err != nil {
+			return resp, wrapTransportError(err)
 		}
 	}
 
-	return nil
+	return resp, nil
 }
 
 // get makes a GET request to the OpenAI API
-func (c *Client) get(ctx context.Context, path string, v interface{}) error {
-	return c.request(ctx, http.MethodGet, path, nil, v)
+func (c *Client) get(ctx context.Context, path string, v interface{}, options ...RequestOption) (*Response, error) {
+	return c.request(ctx, http.MethodGet, path, nil, v, options...)
 }
 
 // post makes a POST request to the OpenAI API
-func (c *Client) post(ctx context.Context, path string, body interface{}, v interface{}) error {
-	return c.request(ctx, http.MethodPost, path, body, v)
+func (c *Client) post(ctx context.Context, path string, body interface{}, v interface{}, options ...RequestOption) (*Response, error) {
+	return c.request(ctx, http.MethodPost, path, body, v, options...)
 }
 
 // delete makes a DELETE request to the OpenAI API
-func (c *Client) delete(ctx context.Context, path string, v interface{}) error {
-	return c.request(ctx, http.MethodDelete, path, nil, v)
+func (c *Client) delete(ctx context.Context, path string, v interface{}, options ...RequestOption) (*Response, error) {
+	return c.request(ctx, http.MethodDelete, path, nil, v, options...)
 }
\ No newline at end of file
@@ -0,0 +1,230 @@
+package client
+
+import (
+	"container/heap"
+	"context"
+	"io"
+	"sync"
+	"time"
+)
+
+// Priority orders calls waiting for a slot of the concurrency limiter. Higher priorities are served first,
+// and calls of equal priority are served in arrival order.
+type Priority int
+
+const (
+	// PriorityLow is meant for batch and background work
+	PriorityLow Priority = -10
+	// PriorityNormal is the priority of calls without an explicit priority
+	PriorityNormal Priority = 0
+	// PriorityHigh is meant for interactive, user-facing traffic
+	PriorityHigh Priority = 10
+)
+
+// priorityKey is the context key for the call priority
+type priorityKey struct{}
+
+// ContextWithPriority returns a context that carries the priority for calls made with it
+func ContextWithPriority(ctx context.Context, priority Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, priority)
+}
+
+// PriorityFromContext returns the priority carried by ctx, or PriorityNormal if there is none
+func PriorityFromContext(ctx context.Context) Priority {
+	if priority, ok := ctx.Value(priorityKey{}).(Priority); ok {
+		return priority
+	}
+	return PriorityNormal
+}
+
+// WithPriority sets the priority of the call, taking precedence over a priority carried by the context
+func WithPriority(priority Priority) RequestOption {
+	return func(r *Request) {
+		r.Priority = &priority
+	}
+}
+
+// ConcurrencyStats is a snapshot of the state of a concurrency limiter
+type ConcurrencyStats struct {
+	// MaxInFlight is the maximum number of concurrent calls
+	MaxInFlight int
+	// InFlight is the number of calls currently holding a slot
+	InFlight int
+	// Queued is the number of calls waiting for a slot
+	Queued int
+}
+
+// ConcurrencyLimiter bounds the number of calls in flight and queues the remaining calls by priority.
+// A stream holds its slot until it is closed. It is safe for concurrent use and may be shared between clients.
+type ConcurrencyLimiter struct {
+	max int
+
+	mu       sync.Mutex
+	inFlight int
+	queue    waitQueue
+	seq      uint64
+}
+
+// NewConcurrencyLimiter creates a limiter allowing at most maxInFlight concurrent calls
+func NewConcurrencyLimiter(maxInFlight int) *ConcurrencyLimiter {
+	return &ConcurrencyLimiter{max: max(maxInFlight, 1)}
+}
+
+// WithConcurrencyLimiter sets a limiter that bounds the number of concurrent calls of the client
+func WithConcurrencyLimiter(limiter *ConcurrencyLimiter) ClientOption {
+	return func(c *Client) {
+		c.concurrencyLimiter = limiter
+	}
+}
+
+// Stats returns a snapshot of the limiter state
+func (l *ConcurrencyLimiter) Stats() ConcurrencyStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return ConcurrencyStats{
+		MaxInFlight: l.max,
+		InFlight:    l.inFlight,
+		Queued:      len(l.queue),
+	}
+}
+
+// Acquire blocks until a slot is available or ctx is done. Queued calls are removed from the queue
+// as soon as their context ends. The returned function releases the slot and may be called more than once.
+func (l *ConcurrencyLimiter) Acquire(ctx context.Context, priority Priority) (func(), error) {
+	l.mu.Lock()
+	if l.inFlight < l.max && len(l.queue) == 0 {
+		l.inFlight++
+		l.mu.Unlock()
+		return l.releaser(), nil
+	}
+
+	w := &waiter{priority: priority, seq: l.seq, ready: make(chan struct{})}
+	l.seq++
+	heap.Push(&l.queue, w)
+	l.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return l.releaser(), nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		select {
+		case <-w.ready:
+			// The slot was granted while the context ended, hand it on
+			l.inFlight--
+			l.dispatch()
+		default:
+			heap.Remove(&l.queue, w.index)
+		}
+		return nil, ctx.Err()
+	}
+}
+
+// releaser returns a function releasing one slot exactly once
+func (l *ConcurrencyLimiter) releaser() func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			l.inFlight--
+			l.dispatch()
+		})
+	}
+}
+
+// dispatch grants free slots to the waiters with the highest priority. l.mu must be held.
+func (l *ConcurrencyLimiter) dispatch() {
+	for l.inFlight < l.max && len(l.queue) > 0 {
+		w := heap.Pop(&l.queue).(*waiter)
+		l.inFlight++
+		close(w.ready)
+	}
+}
+
+// waiter is a call waiting for a slot
+type waiter struct {
+	priority Priority
+	seq      uint64
+	index    int
+	ready    chan struct{}
+}
+
+// waitQueue is a heap of waiters ordered by priority, then arrival
+type waitQueue []*waiter
+
+func (q waitQueue) Len() int { return len(q) }
+
+func (q waitQueue) Less(i, j int) bool {
+	if q[i].priority != q[j].priority {
+		return q[i].priority > q[j].priority
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q waitQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *waitQueue) Push(x interface{}) {
+	w := x.(*waiter)
+	w.index = len(*q)
+	*q = append(*q, w)
+}
+
+func (q *waitQueue) Pop() interface{} {
+	old := *q
+	w := old[len(old)-1]
+	old[len(old)-1] = nil
+	*q = old[:len(old)-1]
+	return w
+}
+
+// releaseOnClose releases a concurrency slot once the response body is closed
+type releaseOnClose struct {
+	io.ReadCloser
+	release func()
+}
+
+// Close closes the body and releases the slot
+func (b *releaseOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
+
+// limit is the pipeline stage that holds a slot of the concurrency limiter for the duration of a call.
+// The slot stays taken until the response body is closed, so streams count as in flight until closed.
+func (c *Client) limit(next Handler) Handler {
+	return func(ctx context.Context, req *Request) (*Response, error) {
+		priority := PriorityFromContext(ctx)
+		if req.Priority != nil {
+			priority = *req.Priority
+		}
+
+		start := time.Now()
+		release, err := c.concurrencyLimiter.Acquire(ctx, priority)
+		wait := time.Since(start)
+		if c.meter != nil {
+			c.meter.RecordHistogram(ctx, MetricQueueWait, wait.Seconds(), Attr(AttrRequestPriority, int(priority)))
+		}
+		if err != nil {
+			return nil, wrapTransportError(err)
+		}
+
+		resp, err := next(ctx, req)
+		if err != nil {
+			release()
+			return nil, err
+		}
+		if resp.Metadata != nil {
+			resp.Metadata.QueueWait = wait
+		}
+		resp.HTTP.Body = &releaseOnClose{ReadCloser: resp.HTTP.Body, release: release}
+		return resp, nil
+	}
+}
@@ -0,0 +1,141 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gosticks/openai-responses-api-go/models"
+)
+
+func TestConcurrencyLimiterRemovesCanceledWaiter(t *testing.T) {
+	limiter := NewConcurrencyLimiter(1)
+	release, err := limiter.Acquire(context.Background(), PriorityNormal)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		_, err := limiter.Acquire(ctx, PriorityHigh)
+		done <- err
+	}()
+	waitFor(t, func() bool { return limiter.Stats().Queued == 1 })
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("got error %v, want context.Canceled", err)
+	}
+	if stats := limiter.Stats(); stats.Queued != 0 || stats.InFlight != 1 {
+		t.Fatalf("got %+v after cancellation, want 1 in flight and none queued", stats)
+	}
+
+	// The released slot goes to the next waiter, not the canceled one
+	granted := make(chan func())
+	go func() {
+		release, err := limiter.Acquire(context.Background(), PriorityLow)
+		if err != nil {
+			t.Error(err)
+		}
+		granted <- release
+	}()
+	waitFor(t, func() bool { return limiter.Stats().Queued == 1 })
+	release()
+	(<-granted)()
+	if stats := limiter.Stats(); stats.Queued != 0 || stats.InFlight != 0 {
+		t.Fatalf("got %+v after release, want an idle limiter", stats)
+	}
+}
+
+func TestConcurrencyLimiterQueuesCalls(t *testing.T) {
+	unblock := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-unblock
+		io.WriteString(w, `{"id":"resp_1","status":"completed"}`)
+	}))
+	defer server.Close()
+
+	limiter := NewConcurrencyLimiter(1)
+	c := NewClient(WithAPIKey("key"), WithBaseURL(server.URL), WithConcurrencyLimiter(limiter))
+	responses := NewResponses(c)
+	request := models.ResponseRequest{Model: "m"}
+
+	first := make(chan error)
+	go func() {
+		_, err := responses.Create(context.Background(), request)
+		first <- err
+	}()
+	waitFor(t, func() bool { return limiter.Stats().InFlight == 1 })
+
+	// A queued call whose context ends leaves the queue without taking the slot
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := responses.Create(ctx, request); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got error %v, want context.DeadlineExceeded", err)
+	}
+	if stats := limiter.Stats(); stats.Queued != 0 || stats.InFlight != 1 {
+		t.Fatalf("got %+v, want 1 in flight and none queued", stats)
+	}
+
+	close(unblock)
+	if err := <-first; err != nil {
+		t.Fatal(err)
+	}
+	if _, err := responses.Create(context.Background(), request); err != nil {
+		t.Fatal(err)
+	}
+	if stats := limiter.Stats(); stats.InFlight != 0 {
+		t.Fatalf("got %+v, want no call in flight", stats)
+	}
+}
+
+// waitFor polls condition until it holds or the test times out
+func waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestConcurrencyLimiterQueueExcludedFromConnectTimeout(t *testing.T) {
+	server := newHangingStreamServer(t)
+	limiter := NewConcurrencyLimiter(1)
+	c := NewClient(
+		WithAPIKey("key"),
+		WithBaseURL(server.URL),
+		WithConcurrencyLimiter(limiter),
+		WithStreamTimeouts(StreamTimeouts{Connect: 50 * time.Millisecond}),
+	)
+	responses := NewResponses(c)
+
+	first, err := responses.CreateStream(context.Background(), models.ResponseRequest{Model: "m"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The second stream queues for longer than the connect timeout
+	go func() {
+		for limiter.Stats().Queued == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		time.Sleep(100 * time.Millisecond)
+		first.Close()
+	}()
+	second, err := responses.CreateStream(context.Background(), models.ResponseRequest{Model: "m"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer second.Close()
+	if _, err := second.Recv(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,340 @@
+package client
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Providers supported by Config
+const (
+	// ProviderOpenAI targets the OpenAI API or a gateway in front of it
+	ProviderOpenAI = "openai"
+	// ProviderAzure targets an Azure OpenAI resource
+	ProviderAzure = "azure"
+	// ProviderLocal targets a local OpenAI compatible server that needs no API key
+	ProviderLocal = "local"
+)
+
+// DefaultProfile is the profile used when none is selected
+const DefaultProfile = "default"
+
+// Config is a client configuration loaded from environment variables and profile files.
+// Zero values keep the client defaults.
+type Config struct {
+	// Provider is ProviderOpenAI, ProviderAzure or ProviderLocal. If empty, it is ProviderAzure
+	// when an Azure endpoint is set and ProviderOpenAI otherwise.
+	Provider string
+	// APIKey is the API key
+	APIKey string
+	// BaseURL is the base URL of the OpenAI API or a compatible server
+	BaseURL string
+	// Organization is the organization ID
+	Organization string
+	// Project is the project ID
+	Project string
+	// Timeout is the total timeout for non-streaming requests
+	Timeout time.Duration
+	// StreamTimeouts override the default stream timeouts where non-zero
+	StreamTimeouts StreamTimeouts
+	// MaxRetries enables retries with the default retry policy when positive
+	MaxRetries int
+	// AzureEndpoint is the Azure OpenAI resource endpoint
+	AzureEndpoint string
+	// AzureAPIVersion is the Azure OpenAI api-version
+	AzureAPIVersion string
+	// AzureDeployment is the Azure OpenAI deployment
+	AzureDeployment string
+	// AzureV1 routes Azure OpenAI requests to the v1 API
+	AzureV1 bool
+}
+
+// LoadConfig loads the configuration of the given profile from a profile file and overlays the
+// environment variables, which take precedence over the file except for the API key. If path is empty,
+// the file named by OPENAI_CONFIG_FILE is used, if any. If profile is empty, OPENAI_PROFILE or
+// DefaultProfile is used.
+//
+// The profile file holds "key = value" lines grouped into profiles by "[name]" headers. Lines before
+// the first header apply to every profile, and lines starting with "#" or ";" are comments:
+//
+//	timeout = 60s
+//
+//	[dev]
+//	provider = local
+//	base_url = http://localhost:8080/v1
+//
+//	[prod]
+//	api_key_env = PROD_OPENAI_KEY
+//	project = proj_abc123
+//	max_retries = 3
+//
+// Supported keys are provider, api_key, api_key_env (the name of a variable holding the key), base_url,
+// organization, project, timeout, stream_connect_timeout, stream_first_event_timeout, stream_idle_timeout,
+// max_retries, azure_endpoint, azure_api_version, azure_deployment and azure_v1.
+//
+// The environment variables are OPENAI_API_KEY, OPENAI_BASE_URL, OPENAI_ORG_ID, OPENAI_PROJECT_ID,
+// OPENAI_TIMEOUT, OPENAI_STREAM_CONNECT_TIMEOUT, OPENAI_STREAM_FIRST_EVENT_TIMEOUT,
+// OPENAI_STREAM_IDLE_TIMEOUT, OPENAI_MAX_RETRIES, AZURE_OPENAI_API_KEY, AZURE_OPENAI_ENDPOINT,
+// AZURE_OPENAI_API_VERSION and AZURE_OPENAI_DEPLOYMENT. OPENAI_API_KEY or AZURE_OPENAI_API_KEY is
+// only used if the profile sets no key, and never for the local provider. Durations are Go durations
+// such as "90s" or plain seconds.
+func LoadConfig(path, profile string) (*Config, error) {
+	if path == "" {
+		path = os.Getenv("OPENAI_CONFIG_FILE")
+	}
+	if profile == "" {
+		profile = os.Getenv("OPENAI_PROFILE")
+	}
+	if profile == "" {
+		profile = DefaultProfile
+	}
+
+	config := &Config{}
+	if path != "" {
+		values, err := readProfile(path, profile)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range values {
+			if err := config.set(v.key, v.value); err != nil {
+				return nil, fmt.Errorf("openai: %s:%d: %w", path, v.line, err)
+			}
+		}
+	}
+
+	if err := config.loadEnv(); err != nil {
+		return nil, err
+	}
+
+	if config.Provider == "" {
+		config.Provider = ProviderOpenAI
+		if config.AzureEndpoint != "" {
+			config.Provider = ProviderAzure
+		}
+	}
+	return config, config.validate()
+}
+
+// Options returns the client options for the configuration
+func (c *Config) Options() []ClientOption {
+	var options []ClientOption
+	if c.APIKey != "" {
+		options = append(options, WithAPIKey(c.APIKey))
+	}
+	if c.Provider == ProviderAzure {
+		var azureOptions []AzureOption
+		if c.AzureDeployment != "" {
+			azureOptions = append(azureOptions, WithAzureDeployment(c.AzureDeployment))
+		}
+		if c.AzureV1 {
+			azureOptions = append(azureOptions, WithAzureV1())
+		}
+		options = append(options, WithAzure(c.AzureEndpoint, c.AzureAPIVersion, azureOptions...))
+	} else if c.BaseURL != "" {
+		options = append(options, WithBaseURL(c.BaseURL))
+	}
+	if c.Organization != "" {
+		options = append(options, WithOrganization(c.Organization))
+	}
+	if c.Project != "" {
+		options = append(options, WithProject(c.Project))
+	}
+	if c.Timeout > 0 {
+		options = append(options, WithTimeout(c.Timeout))
+	}
+	if c.StreamTimeouts != (StreamTimeouts{}) {
+		timeouts := DefaultStreamTimeouts()
+		if c.StreamTimeouts.Connect > 0 {
+			timeouts.Connect = c.StreamTimeouts.Connect
+		}
+		if c.StreamTimeouts.FirstEvent > 0 {
+			timeouts.FirstEvent = c.StreamTimeouts.FirstEvent
+		}
+		if c.StreamTimeouts.Idle > 0 {
+			timeouts.Idle = c.StreamTimeouts.Idle
+		}
+		options = append(options, WithStreamTimeouts(timeouts))
+	}
+	if c.MaxRetries > 0 {
+		policy := DefaultRetryPolicy()
+		policy.MaxAttempts = c.MaxRetries + 1
+		options = append(options, WithRetryPolicy(policy))
+	}
+	return options
+}
+
+// validate checks that the configuration is complete for its provider
+func (c *Config) validate() error {
+	switch c.Provider {
+	case ProviderOpenAI:
+	case ProviderAzure:
+		if c.AzureEndpoint == "" {
+			return fmt.Errorf("openai: provider %q requires azure_endpoint", c.Provider)
+		}
+	case ProviderLocal:
+		if c.BaseURL == "" {
+			return fmt.Errorf("openai: provider %q requires base_url", c.Provider)
+		}
+		if c.APIKey == "" {
+			// Local servers ignore the key, but the client would otherwise fall back to OPENAI_API_KEY
+			c.APIKey = "local"
+		}
+	default:
+		return fmt.Errorf("openai: unknown provider %q", c.Provider)
+	}
+	return nil
+}
+
+// loadEnv overlays the configuration with the environment variables that are set
+func (c *Config) loadEnv() error {
+	vars := []struct{ env, key string }{
+		{"OPENAI_BASE_URL", "base_url"},
+		{"OPENAI_ORG_ID", "organization"},
+		{"OPENAI_PROJECT_ID", "project"},
+		{"OPENAI_TIMEOUT", "timeout"},
+		{"OPENAI_STREAM_CONNECT_TIMEOUT", "stream_connect_timeout"},
+		{"OPENAI_STREAM_FIRST_EVENT_TIMEOUT", "stream_first_event_timeout"},
+		{"OPENAI_STREAM_IDLE_TIMEOUT", "stream_idle_timeout"},
+		{"OPENAI_MAX_RETRIES", "max_retries"},
+		{"AZURE_OPENAI_ENDPOINT", "azure_endpoint"},
+		{"AZURE_OPENAI_API_VERSION", "azure_api_version"},
+		{"AZURE_OPENAI_DEPLOYMENT", "azure_deployment"},
+	}
+	for _, v := range vars {
+		if value := os.Getenv(v.env); value != "" {
+			if err := c.set(v.key, value); err != nil {
+				return fmt.Errorf("openai: %s: %w", v.env, err)
+			}
+		}
+	}
+
+	// A key from the profile takes precedence so that each profile can select its own key
+	if c.APIKey == "" {
+		switch {
+		case c.Provider == ProviderLocal:
+			// The OpenAI key must not be sent to a local or third-party server
+		case c.Provider == ProviderAzure || c.Provider == "" && c.AzureEndpoint != "":
+			c.APIKey = os.Getenv("AZURE_OPENAI_API_KEY")
+		default:
+			c.APIKey = os.Getenv("OPENAI_API_KEY")
+		}
+	}
+	return nil
+}
+
+// set sets the configuration value for a profile file key
+func (c *Config) set(key, value string) error {
+	var err error
+	switch key {
+	case "provider":
+		c.Provider = strings.ToLower(value)
+	case "api_key":
+		c.APIKey = value
+	case "api_key_env":
+		// An unset variable must not silently fall back to OPENAI_API_KEY, which may hold another key
+		c.APIKey = os.Getenv(value)
+		if c.APIKey == "" {
+			err = fmt.Errorf("environment variable %s is not set", value)
+		}
+	case "base_url":
+		c.BaseURL = value
+	case "organization":
+		c.Organization = value
+	case "project":
+		c.Project = value
+	case "timeout":
+		c.Timeout, err = parseDuration(value)
+	case "stream_connect_timeout":
+		c.StreamTimeouts.Connect, err = parseDuration(value)
+	case "stream_first_event_timeout":
+		c.StreamTimeouts.FirstEvent, err = parseDuration(value)
+	case "stream_idle_timeout":
+		c.StreamTimeouts.Idle, err = parseDuration(value)
+	case "max_retries":
+		c.MaxRetries, err = strconv.Atoi(value)
+	case "azure_endpoint":
+		c.AzureEndpoint = value
+	case "azure_api_version":
+		c.AzureAPIVersion = value
+	case "azure_deployment":
+		c.AzureDeployment = value
+	case "azure_v1":
+		c.AzureV1, err = strconv.ParseBool(value)
+	default:
+		return fmt.Errorf("unknown key %q", key)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+	return nil
+}
+
+// profileValue is a key-value pair read from a profile file
+type profileValue struct {
+	key   string
+	value string
+	line  int
+}
+
+// readProfile reads the global values and the values of the given profile from a profile file
+func readProfile(path, profile string) ([]profileValue, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var values []profileValue
+	section := ""
+	found := false
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") || strings.HasPrefix(text, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(text, "[") && strings.HasSuffix(text, "]") {
+			section = strings.TrimSpace(text[1 : len(text)-1])
+			if section == profile {
+				found = true
+			}
+			continue
+		}
+
+		key, value, ok := strings.Cut(text, "=")
+		if !ok {
+			return nil, fmt.Errorf("openai: %s:%d: expected key = value", path, line)
+		}
+		if section == "" || section == profile {
+			values = append(values, profileValue{
+				key:   strings.TrimSpace(key),
+				value: strings.Trim(strings.TrimSpace(value), `"`),
+				line:  line,
+			})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if !found && profile != DefaultProfile {
+		return nil, fmt.Errorf("openai: profile %q not found in %s", profile, path)
+	}
+	return values, nil
+}
+
+// parseDuration parses a Go duration such as "90s", or a plain number of seconds
+func parseDuration(value string) (time.Duration, error) {
+	if d, err := time.ParseDuration(value); err == nil {
+		return d, nil
+	}
+	secs, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	return time.Duration(secs * float64(time.Second)), nil
+}
@@ -0,0 +1,48 @@
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadConfigAPIKeyEnv(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	profile := "[prod]\napi_key_env = TEST_PROD_OPENAI_KEY\n"
+	if err := os.WriteFile(path, []byte(profile), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("OPENAI_API_KEY", "dev-key")
+
+	t.Setenv("TEST_PROD_OPENAI_KEY", "prod-key")
+	config, err := LoadConfig(path, "prod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.APIKey != "prod-key" {
+		t.Errorf("got API key %q, want prod-key", config.APIKey)
+	}
+
+	t.Setenv("TEST_PROD_OPENAI_KEY", "")
+	if _, err := LoadConfig(path, "prod"); err == nil || !strings.Contains(err.Error(), "TEST_PROD_OPENAI_KEY") {
+		t.Errorf("got error %v, want error naming the unset variable", err)
+	}
+}
+
+func TestLoadConfigLocalIgnoresOpenAIKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	profile := "[dev]\nprovider = local\nbase_url = http://localhost:1234/v1\n"
+	if err := os.WriteFile(path, []byte(profile), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("OPENAI_API_KEY", "sk-real-secret")
+
+	config, err := LoadConfig(path, "dev")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.APIKey != "local" {
+		t.Errorf("got API key %q, want local", config.APIKey)
+	}
+}
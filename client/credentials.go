@@ -0,0 +1,241 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultRefreshLeeway is how long before expiry a refreshing credential fetches a new token
+	DefaultRefreshLeeway = time.Minute
+	// DefaultBenchDuration is how long a credential pool stops using a key after a 401 or 429
+	DefaultBenchDuration = time.Minute
+)
+
+// ErrNoCredential is returned when a credential provider has no credential available
+var ErrNoCredential = errors.New("openai: no credential available")
+
+// CredentialProvider supplies the API key or bearer token for requests.
+// It is consulted for every request attempt and stream open, and must be safe for concurrent use.
+type CredentialProvider interface {
+	// Credential returns the API key or token to use for the next request
+	Credential(ctx context.Context) (string, error)
+}
+
+// CredentialReporter is implemented by credential providers that react to the outcome of requests
+type CredentialReporter interface {
+	// Report is called with the credential used for a request and the response status code
+	Report(credential string, statusCode int)
+}
+
+// WithCredentialProvider sets the credential provider for the client. It takes precedence over the API key.
+func WithCredentialProvider(provider CredentialProvider) ClientOption {
+	return func(c *Client) {
+		c.credentials = provider
+	}
+}
+
+// StaticCredential returns a credential provider that always returns the given key
+func StaticCredential(key string) CredentialProvider {
+	return staticCredential(key)
+}
+
+// staticCredential is a credential provider for a fixed key
+type staticCredential string
+
+// Credential returns the fixed key
+func (s staticCredential) Credential(ctx context.Context) (string, error) {
+	if s == "" {
+		return "", ErrNoCredential
+	}
+	return string(s), nil
+}
+
+// EnvCredential returns a credential provider that reads the key from the environment variable
+// on every request, so that rotated keys are picked up
+func EnvCredential(name string) CredentialProvider {
+	return envCredential(name)
+}
+
+// envCredential is a credential provider backed by an environment variable
+type envCredential string
+
+// Credential returns the current value of the environment variable
+func (e envCredential) Credential(ctx context.Context) (string, error) {
+	key := os.Getenv(string(e))
+	if key == "" {
+		return "", fmt.Errorf("%w: environment variable %s is not set", ErrNoCredential, string(e))
+	}
+	return key, nil
+}
+
+// FileCredential is a credential provider that reads the key from a file and reloads it
+// whenever the file changes, e.g. when a secret is rotated by a mounted volume
+type FileCredential struct {
+	path string
+
+	mu      sync.Mutex
+	key     string
+	modTime time.Time
+	size    int64
+}
+
+// NewFileCredential creates a credential provider backed by the file at path
+func NewFileCredential(path string) *FileCredential {
+	return &FileCredential{path: path}
+}
+
+// Credential returns the key from the file, reloading it if the file was modified
+func (f *FileCredential) Credential(ctx context.Context) (string, error) {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return "", err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.key == "" || !info.ModTime().Equal(f.modTime) || info.Size() != f.size {
+		data, err := os.ReadFile(f.path)
+		if err != nil {
+			return "", err
+		}
+		key := strings.TrimSpace(string(data))
+		if key == "" {
+			return "", fmt.Errorf("%w: file %s is empty", ErrNoCredential, f.path)
+		}
+		f.key, f.modTime, f.size = key, info.ModTime(), info.Size()
+	}
+	return f.key, nil
+}
+
+// TokenSource fetches a short-lived token and its expiry time
+type TokenSource func(ctx context.Context) (token string, expiresAt time.Time, err error)
+
+// RefreshingCredential is a credential provider that caches a short-lived token and
+// fetches a new one shortly before it expires
+type RefreshingCredential struct {
+	source TokenSource
+	leeway time.Duration
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+// NewRefreshingCredential creates a credential provider that refreshes tokens from source
+// DefaultRefreshLeeway before they expire
+func NewRefreshingCredential(source TokenSource) *RefreshingCredential {
+	return &RefreshingCredential{
+		source: source,
+		leeway: DefaultRefreshLeeway,
+	}
+}
+
+// Credential returns the cached token, refreshing it if it is about to expire
+func (r *RefreshingCredential) Credential(ctx context.Context) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.token != "" && time.Until(r.expiresAt) > r.leeway {
+		return r.token, nil
+	}
+
+	token, expiresAt, err := r.source(ctx)
+	if err != nil {
+		return "", err
+	}
+	r.token, r.expiresAt = token, expiresAt
+	return token, nil
+}
+
+// Report drops the cached token when it is rejected, so the next request fetches a new one
+func (r *RefreshingCredential) Report(credential string, statusCode int) {
+	if statusCode != http.StatusUnauthorized {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.token == credential {
+		r.token = ""
+	}
+}
+
+// CredentialPool is a credential provider that rotates between several keys in round-robin
+// order and benches a key for a while after it is rejected (401) or rate limited (429)
+type CredentialPool struct {
+	keys          []string
+	benchDuration time.Duration
+
+	mu      sync.Mutex
+	next    int
+	benched map[string]time.Time
+}
+
+// NewCredentialPool creates a credential pool for the given keys
+func NewCredentialPool(keys ...string) *CredentialPool {
+	return &CredentialPool{
+		keys:          keys,
+		benchDuration: DefaultBenchDuration,
+		benched:       make(map[string]time.Time),
+	}
+}
+
+// WithBenchDuration sets how long a key is benched after a 401 or 429 and returns the pool
+func (p *CredentialPool) WithBenchDuration(d time.Duration) *CredentialPool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.benchDuration = d
+	return p
+}
+
+// Credential returns the next available key. If every key is benched, the key that
+// becomes available first is returned, and it stays benched.
+func (p *CredentialPool) Credential(ctx context.Context) (string, error) {
+	if len(p.keys) == 0 {
+		return "", ErrNoCredential
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	best := -1
+	for i := range p.keys {
+		idx := (p.next + i) % len(p.keys)
+		until, ok := p.benched[p.keys[idx]]
+		if !ok || !now.Before(until) {
+			best = idx
+			break
+		}
+		if best == -1 || until.Before(p.benched[p.keys[best]]) {
+			best = idx
+		}
+	}
+
+	p.next = (best + 1) % len(p.keys)
+	key := p.keys[best]
+	// A key handed out as a fallback while benched stays benched until its bench expires
+	if until, ok := p.benched[key]; ok && !now.Before(until) {
+		delete(p.benched, key)
+	}
+	return key, nil
+}
+
+// Report benches the key after a 401 or 429 response
+func (p *CredentialPool) Report(credential string, statusCode int) {
+	if statusCode != http.StatusUnauthorized && statusCode != http.StatusTooManyRequests {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.benched[credential] = time.Now().Add(p.benchDuration)
+}
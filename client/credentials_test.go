@@ -0,0 +1,34 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestCredentialPoolKeepsBenchOfFallbackKey(t *testing.T) {
+	pool := NewCredentialPool("a", "b").WithBenchDuration(time.Hour)
+	pool.Report("a", http.StatusUnauthorized)
+	pool.Report("b", http.StatusTooManyRequests)
+
+	// Every key is benched, so the key available first is handed out without losing its bench
+	for range 3 {
+		key, err := pool.Credential(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if key != "a" {
+			t.Fatalf("got key %q, want the soonest available key a", key)
+		}
+	}
+
+	// Once b is available again, it is preferred over the still benched a
+	pool.WithBenchDuration(0)
+	pool.Report("b", http.StatusTooManyRequests)
+	for range 3 {
+		if key, _ := pool.Credential(context.Background()); key != "b" {
+			t.Fatalf("got key %q, want the available key b", key)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gosticks/openai-responses-api-go/models"
+)
+
+// Curl renders a curl command equivalent to the call the client would make for req, including the
+// client defaults, request options and the selected tenant. The API key is never included. Instead, the
+// command reads it from the OPENAI_API_KEY, AZURE_OPENAI_API_KEY or AZURE_OPENAI_TOKEN environment
+// variable of the shell. Headers added by middleware or a request signer are not included.
+func (c *Client) Curl(ctx context.Context, req *Request, options ...RequestOption) (string, error) {
+	if err := c.prepare(ctx, req, options...); err != nil {
+		return "", err
+	}
+
+	baseURL := c.defaultBaseURL()
+	if len(c.endpoints) > 0 {
+		baseURL = c.endpoints[0].BaseURL
+	}
+	u, err := c.url(baseURL, req)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	b.WriteString("curl")
+	if req.Stream {
+		b.WriteString(" -N")
+	}
+	if req.Method != http.MethodGet || req.Body != nil {
+		fmt.Fprintf(&b, " -X %s", req.Method)
+	}
+	fmt.Fprintf(&b, " %s", shellQuote(u.String()))
+
+	// Credentials are replaced with a reference to the environment variable holding them
+	header := req.Header.Clone()
+	for _, key := range sensitiveHeaders {
+		header.Del(key)
+	}
+	auth := "Authorization: Bearer $OPENAI_API_KEY"
+	if c.azure != nil && c.azure.TokenProvider != nil {
+		auth = "Authorization: Bearer $AZURE_OPENAI_TOKEN"
+	} else if c.azure != nil {
+		auth = "api-key: $AZURE_OPENAI_API_KEY"
+	}
+	fmt.Fprintf(&b, " \\\n  -H \"%s\"", auth)
+
+	keys := make([]string, 0, len(header))
+	for key := range header {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		for _, value := range header[key] {
+			fmt.Fprintf(&b, " \\\n  -H %s", shellQuote(key+": "+value))
+		}
+	}
+
+	if req.Body != nil {
+		body, err := json.Marshal(req.Body)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&b, " \\\n  --data-raw %s", shellQuote(redactString(string(body))))
+	}
+	return b.String(), nil
+}
+
+// Curl renders a curl command equivalent to the Create or CreateStream call for request,
+// depending on request.Stream
+func (r *Responses) Curl(ctx context.Context, request models.ResponseRequest, options ...RequestOption) (string, error) {
+	return r.client.Curl(ctx, &Request{
+		Method: http.MethodPost,
+		Path:   responsesEndpoint,
+		Body:   request,
+		Stream: request.Stream,
+	}, options...)
+}
+
+// shellQuote quotes s for a POSIX shell
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,252 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/gosticks/openai-responses-api-go/models"
+)
+
+// maxErrorBodyBytes is the maximum number of bytes read from an error response body
+const maxErrorBodyBytes = 1 << 20
+
+// Sentinel errors for use with errors.Is. Every typed error matches its sentinel.
+var (
+	// ErrAuthentication is matched by errors caused by a missing or invalid API key (401)
+	ErrAuthentication = errors.New("openai: authentication failed")
+	// ErrPermissionDenied is matched by errors caused by insufficient permissions (403)
+	ErrPermissionDenied = errors.New("openai: permission denied")
+	// ErrNotFound is matched by errors for resources that do not exist (404)
+	ErrNotFound = errors.New("openai: not found")
+	// ErrRateLimit is matched by errors caused by exceeded rate limits or quotas (429)
+	ErrRateLimit = errors.New("openai: rate limit exceeded")
+	// ErrContextLengthExceeded is matched by errors for requests exceeding the model context window
+	ErrContextLengthExceeded = errors.New("openai: context length exceeded")
+	// ErrServer is matched by errors caused by server-side failures (5xx)
+	ErrServer = errors.New("openai: server error")
+	// ErrTimeout is matched by errors caused by a request or stream timing out
+	ErrTimeout = errors.New("openai: timeout")
+	// ErrStreamInterrupted is matched by errors caused by a stream ending before completion
+	ErrStreamInterrupted = errors.New("openai: stream interrupted")
+	// ErrClientClosed is matched by errors caused by the client being shut down
+	ErrClientClosed = errors.New("openai: client closed")
+	// ErrRefusal is matched by errors caused by the model refusing to produce structured output
+	ErrRefusal = errors.New("openai: model refused")
+)
+
+// AuthenticationError is returned when the API rejects the credentials (401)
+type AuthenticationError struct{ *APIError }
+
+// Unwrap returns the underlying API error
+func (e *AuthenticationError) Unwrap() error { return e.APIError }
+
+// Is reports whether the error matches ErrAuthentication
+func (e *AuthenticationError) Is(target error) bool { return target == ErrAuthentication }
+
+// PermissionDeniedError is returned when the credentials lack access to a resource (403)
+type PermissionDeniedError struct{ *APIError }
+
+// Unwrap returns the underlying API error
+func (e *PermissionDeniedError) Unwrap() error { return e.APIError }
+
+// Is reports whether the error matches ErrPermissionDenied
+func (e *PermissionDeniedError) Is(target error) bool { return target == ErrPermissionDenied }
+
+// NotFoundError is returned when the requested resource does not exist (404)
+type NotFoundError struct{ *APIError }
+
+// Unwrap returns the underlying API error
+func (e *NotFoundError) Unwrap() error { return e.APIError }
+
+// Is reports whether the error matches ErrNotFound
+func (e *NotFoundError) Is(target error) bool { return target == ErrNotFound }
+
+// RateLimitError is returned when a rate limit or quota is exceeded (429)
+type RateLimitError struct {
+	*APIError
+	// RetryAfter is the delay requested by the server before retrying, if any
+	RetryAfter time.Duration
+}
+
+// Unwrap returns the underlying API error
+func (e *RateLimitError) Unwrap() error { return e.APIError }
+
+// Is reports whether the error matches ErrRateLimit
+func (e *RateLimitError) Is(target error) bool { return target == ErrRateLimit }
+
+// Retryable reports whether the request may succeed when retried.
+// Exhausted quotas are not retryable, temporary rate limits are.
+func (e *RateLimitError) Retryable() bool {
+	return e.Code == nil || *e.Code != "insufficient_quota"
+}
+
+// ContextLengthExceededError is returned when the input exceeds the model context window
+type ContextLengthExceededError struct{ *APIError }
+
+// Unwrap returns the underlying API error
+func (e *ContextLengthExceededError) Unwrap() error { return e.APIError }
+
+// Is reports whether the error matches ErrContextLengthExceeded
+func (e *ContextLengthExceededError) Is(target error) bool { return target == ErrContextLengthExceeded }
+
+// ServerError is returned when the API or an intermediate proxy fails (5xx)
+type ServerError struct{ *APIError }
+
+// Unwrap returns the underlying API error
+func (e *ServerError) Unwrap() error { return e.APIError }
+
+// Is reports whether the error matches ErrServer
+func (e *ServerError) Is(target error) bool { return target == ErrServer }
+
+// TimeoutError is returned when a request or stream times out
+type TimeoutError struct {
+	// Err is the underlying error, such as context.DeadlineExceeded
+	Err error
+}
+
+// Error implements the error interface
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("OpenAI API timeout: %v", e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *TimeoutError) Unwrap() error { return e.Err }
+
+// Is reports whether the error matches ErrTimeout
+func (e *TimeoutError) Is(target error) bool { return target == ErrTimeout }
+
+// Retryable reports whether the request may succeed when retried
+func (e *TimeoutError) Retryable() bool { return true }
+
+// StreamInterruptedError is returned when a stream ends before the response completed
+type StreamInterruptedError struct {
+	// Err is the underlying read error
+	Err error
+}
+
+// Error implements the error interface
+func (e *StreamInterruptedError) Error() string {
+	return fmt.Sprintf("OpenAI API stream interrupted: %v", e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *StreamInterruptedError) Unwrap() error { return e.Err }
+
+// Is reports whether the error matches ErrStreamInterrupted
+func (e *StreamInterruptedError) Is(target error) bool { return target == ErrStreamInterrupted }
+
+// Retryable reports whether the request may succeed when retried
+func (e *StreamInterruptedError) Retryable() bool { return true }
+
+// ClientClosedError is returned for calls made after Shutdown, and for calls and streams
+// canceled because they did not finish before the shutdown deadline
+type ClientClosedError struct {
+	// Canceled is true if the call was in flight and canceled by Shutdown
+	Canceled bool
+}
+
+// Error implements the error interface
+func (e *ClientClosedError) Error() string {
+	if e.Canceled {
+		return "OpenAI client shut down: call canceled"
+	}
+	return "OpenAI client shut down"
+}
+
+// Is reports whether the error matches ErrClientClosed
+func (e *ClientClosedError) Is(target error) bool { return target == ErrClientClosed }
+
+// Retryable reports whether the request may succeed when retried
+func (e *ClientClosedError) Retryable() bool { return false }
+
+// RefusalError is returned by Parse when the model refuses to produce the requested structured output
+type RefusalError struct {
+	// Refusal is the refusal message of the model
+	Refusal string
+	// Response is the response holding the refusal
+	Response *models.ResponseResponse
+}
+
+// Error implements the error interface
+func (e *RefusalError) Error() string {
+	return fmt.Sprintf("OpenAI model refused: %s", e.Refusal)
+}
+
+// Is reports whether the error matches ErrRefusal
+func (e *RefusalError) Is(target error) bool { return target == ErrRefusal }
+
+// Retryable reports whether the request may succeed when retried
+func (e *RefusalError) Retryable() bool { return false }
+
+// IsRetryable reports whether the operation that returned err may succeed when retried
+func IsRetryable(err error) bool {
+	var retryable interface{ Retryable() bool }
+	if errors.As(err, &retryable) {
+		return retryable.Retryable()
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && !errors.Is(err, context.Canceled)
+}
+
+// decodeError converts an error response from the API into a typed error.
+// Bodies that are not JSON, such as HTML pages from proxies, are preserved in APIError.RawBody.
+func decodeError(resp *http.Response) error {
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+	if err != nil {
+		return fmt.Errorf("error reading error response: %w", err)
+	}
+
+	var errResp ErrorResponse
+	apiErr := &APIError{}
+	if json.Unmarshal(body, &errResp) == nil && errResp.Error != nil {
+		apiErr = errResp.Error
+	} else {
+		apiErr.RawBody = string(body)
+		apiErr.Message = http.StatusText(resp.StatusCode)
+	}
+	apiErr.StatusCode = resp.StatusCode
+	apiErr.RequestID = resp.Header.Get("x-request-id")
+
+	return classifyError(apiErr, resp.Header)
+}
+
+// classifyError wraps an API error in the typed error matching its status code and error code
+func classifyError(apiErr *APIError, header http.Header) error {
+	if apiErr.Code != nil && *apiErr.Code == "context_length_exceeded" {
+		return &ContextLengthExceededError{apiErr}
+	}
+
+	switch {
+	case apiErr.StatusCode == http.StatusUnauthorized:
+		return &AuthenticationError{apiErr}
+	case apiErr.StatusCode == http.StatusForbidden:
+		return &PermissionDeniedError{apiErr}
+	case apiErr.StatusCode == http.StatusNotFound:
+		return &NotFoundError{apiErr}
+	case apiErr.StatusCode == http.StatusTooManyRequests:
+		rateLimitErr := &RateLimitError{APIError: apiErr}
+		if header != nil {
+			rateLimitErr.RetryAfter, _ = retryAfter(header)
+		}
+		return rateLimitErr
+	case apiErr.StatusCode >= 500:
+		return &ServerError{apiErr}
+	}
+	return apiErr
+}
+
+// wrapTransportError converts timeouts from the HTTP client or context into a TimeoutError
+func wrapTransportError(err error) error {
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || errors.As(err, &netErr) && netErr.Timeout() {
+		return &TimeoutError{Err: err}
+	}
+	return err
+}
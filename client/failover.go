@@ -0,0 +1,339 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// healthSmoothing is the weight of the latest observation in the error rate and latency averages
+	healthSmoothing = 0.2
+)
+
+// Endpoint is an API endpoint the client can fail over between
+type Endpoint struct {
+	// BaseURL is the base URL of the endpoint, e.g. "https://gateway-eu.example.com/v1". With Azure
+	// OpenAI, it is the resource endpoint, e.g. "https://my-resource-eastus.openai.azure.com", and the
+	// deployment, API version and authentication of WithAzure apply to every endpoint.
+	BaseURL string
+	// Credentials supplies the API key for the endpoint. If nil, the client credentials are used.
+	Credentials CredentialProvider
+	// Weight is the relative share of traffic sent to the endpoint. If every endpoint has a weight
+	// of 0, endpoints are tried in order and later ones only serve as fallbacks.
+	Weight int
+}
+
+// CircuitBreakerConfig configures when an endpoint is taken out of rotation
+type CircuitBreakerConfig struct {
+	// FailureThreshold is the number of consecutive failures after which the circuit opens
+	FailureThreshold int
+	// OpenDuration is how long an open circuit rejects traffic before a trial request is let through
+	OpenDuration time.Duration
+}
+
+// DefaultCircuitBreakerConfig returns the default circuit breaker configuration
+func DefaultCircuitBreakerConfig() CircuitBreakerConfig {
+	return CircuitBreakerConfig{
+		FailureThreshold: 5,
+		OpenDuration:     30 * time.Second,
+	}
+}
+
+// CircuitState is the state of an endpoint circuit breaker
+type CircuitState string
+
+const (
+	// CircuitClosed means the endpoint is healthy and receives traffic
+	CircuitClosed CircuitState = "closed"
+	// CircuitOpen means the endpoint is failing and is skipped
+	CircuitOpen CircuitState = "open"
+	// CircuitHalfOpen means the endpoint is being probed with a trial request
+	CircuitHalfOpen CircuitState = "half-open"
+)
+
+// EndpointHealth is a snapshot of the health of an endpoint
+type EndpointHealth struct {
+	// BaseURL is the base URL of the endpoint
+	BaseURL string
+	// State is the circuit breaker state
+	State CircuitState
+	// ConsecutiveFailures is the number of failures since the last success
+	ConsecutiveFailures int
+	// ErrorRate is the exponentially weighted share of failed requests, between 0 and 1
+	ErrorRate float64
+	// Latency is the exponentially weighted time until response headers were received
+	Latency time.Duration
+}
+
+// WithEndpoints configures several endpoints for the client. Requests, including stream
+// establishment, go to a healthy endpoint and fail over to the next one on connection errors,
+// 429 and 5xx responses. The endpoints replace the base URL.
+func WithEndpoints(endpoints ...Endpoint) ClientOption {
+	return func(c *Client) {
+		c.endpoints = endpoints
+	}
+}
+
+// WithCircuitBreaker sets the circuit breaker configuration used with multiple endpoints
+func WithCircuitBreaker(config CircuitBreakerConfig) ClientOption {
+	return func(c *Client) {
+		c.circuitBreaker = config
+	}
+}
+
+// EndpointHealth returns a snapshot of the health of the configured endpoints,
+// or nil if the client uses a single base URL
+func (c *Client) EndpointHealth() []EndpointHealth {
+	if c.pool == nil {
+		return nil
+	}
+	return c.pool.health()
+}
+
+// failover sends the request to the healthy endpoints in turn until one of them succeeds
+func (c *Client) failover(ctx context.Context, req *Request, jsonBody []byte) (*http.Response, error) {
+	candidates, lastResort := c.pool.candidates()
+
+	var resp *http.Response
+	err := errors.New("openai: no endpoint available")
+	for _, ep := range candidates {
+		trial, ok := c.pool.claim(ep)
+		if !ok && !lastResort {
+			// Another call is probing the half-open endpoint
+			continue
+		}
+		if resp != nil {
+			// Drain the body of the failed attempt so the connection can be reused
+			io.CopyN(io.Discard, resp.Body, maxDrainBytes)
+			resp.Body.Close()
+		}
+
+		start := time.Now()
+		resp, err = c.attempt(ctx, req, jsonBody, ep.BaseURL, ep.credentials(c.credentials))
+		if ctx.Err() != nil {
+			if trial {
+				c.pool.release(ep)
+			}
+			return resp, err
+		}
+
+		failed := shouldFailover(resp, err)
+		c.pool.record(ep, time.Since(start), failed)
+		if !failed {
+			return resp, err
+		}
+	}
+	return resp, err
+}
+
+// sameEndpoints reports whether a and b are the same endpoint list
+func sameEndpoints(a, b []Endpoint) bool {
+	return len(a) == len(b) && (len(a) == 0 || &a[0] == &b[0])
+}
+
+// shouldFailover reports whether a call that produced resp and err should move on to the next endpoint
+func shouldFailover(resp *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, context.Canceled)
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
+
+// endpointPool tracks the health of a set of endpoints
+type endpointPool struct {
+	config   CircuitBreakerConfig
+	weighted bool
+
+	mu        sync.Mutex
+	endpoints []*endpointState
+}
+
+// endpointState is the health state of a single endpoint
+type endpointState struct {
+	Endpoint
+
+	failures  int
+	openedAt  time.Time
+	trialAt   time.Time
+	errorRate float64
+	latency   time.Duration
+}
+
+// credentials returns the credential provider of the endpoint, falling back to the client provider
+func (e *endpointState) credentials(fallback CredentialProvider) CredentialProvider {
+	if e.Credentials != nil {
+		return e.Credentials
+	}
+	return fallback
+}
+
+// newEndpointPool creates a pool for the given endpoints
+func newEndpointPool(endpoints []Endpoint, config CircuitBreakerConfig) *endpointPool {
+	pool := &endpointPool{config: config}
+	for _, ep := range endpoints {
+		pool.endpoints = append(pool.endpoints, &endpointState{Endpoint: ep})
+		if ep.Weight > 0 {
+			pool.weighted = true
+		}
+	}
+	return pool
+}
+
+// state returns the circuit state of an endpoint
+func (p *endpointPool) state(ep *endpointState, now time.Time) CircuitState {
+	if p.config.FailureThreshold <= 0 || ep.failures < p.config.FailureThreshold {
+		return CircuitClosed
+	}
+	if now.Sub(ep.openedAt) < p.config.OpenDuration {
+		return CircuitOpen
+	}
+	return CircuitHalfOpen
+}
+
+// candidates returns the endpoints to try for a call in order of preference. Endpoints with an
+// open circuit are skipped, as are half-open endpoints whose trial request is in flight. If no
+// endpoint is available, the first endpoint is returned as a last resort and lastResort is true.
+func (p *endpointPool) candidates() (candidates []*endpointState, lastResort bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	for _, ep := range p.order() {
+		switch p.state(ep, now) {
+		case CircuitClosed:
+			candidates = append(candidates, ep)
+		case CircuitHalfOpen:
+			if !p.probing(ep, now) {
+				candidates = append(candidates, ep)
+			}
+		}
+	}
+
+	if len(candidates) == 0 && len(p.endpoints) > 0 {
+		return []*endpointState{p.endpoints[0]}, true
+	}
+	return candidates, false
+}
+
+// claim reserves the single trial request of a half-open endpoint right before it is tried, so that
+// endpoints are only excluded while they are actually probed. It reports whether the endpoint is
+// in trial and whether it may be tried, which is false if another call is probing it.
+func (p *endpointPool) claim(ep *endpointState) (trial, ok bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	if p.state(ep, now) != CircuitHalfOpen {
+		return false, true
+	}
+	if p.probing(ep, now) {
+		return false, false
+	}
+	ep.trialAt = now
+	return true, true
+}
+
+// release gives up the trial claim of an endpoint whose trial ended without an outcome
+func (p *endpointPool) release(ep *endpointState) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ep.trialAt = time.Time{}
+}
+
+// probing reports whether a trial request of a half-open endpoint is in flight. Trials that never
+// reported back expire after another open period. p.mu must be held.
+func (p *endpointPool) probing(ep *endpointState, now time.Time) bool {
+	return now.Sub(ep.trialAt) < p.config.OpenDuration
+}
+
+// order returns the endpoints in configured order, or shuffled by weight in weighted mode
+func (p *endpointPool) order() []*endpointState {
+	if !p.weighted {
+		return p.endpoints
+	}
+
+	remaining := make([]*endpointState, 0, len(p.endpoints))
+	total := 0
+	for _, ep := range p.endpoints {
+		if ep.Weight > 0 {
+			remaining = append(remaining, ep)
+			total += ep.Weight
+		}
+	}
+
+	ordered := make([]*endpointState, 0, len(p.endpoints))
+	for len(remaining) > 0 {
+		n := rand.IntN(total)
+		for i, ep := range remaining {
+			if n < ep.Weight {
+				ordered = append(ordered, ep)
+				total -= ep.Weight
+				remaining = append(remaining[:i], remaining[i+1:]...)
+				break
+			}
+			n -= ep.Weight
+		}
+	}
+
+	// Endpoints without weight only serve as fallbacks
+	for _, ep := range p.endpoints {
+		if ep.Weight <= 0 {
+			ordered = append(ordered, ep)
+		}
+	}
+	return ordered
+}
+
+// record updates the health of an endpoint after a call
+func (p *endpointPool) record(ep *endpointState, latency time.Duration, failed bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	outcome := 0.0
+	if failed {
+		outcome = 1
+	}
+	ep.errorRate += healthSmoothing * (outcome - ep.errorRate)
+	if ep.latency == 0 {
+		ep.latency = latency
+	} else {
+		ep.latency += time.Duration(healthSmoothing * float64(latency-ep.latency))
+	}
+
+	if !failed {
+		ep.failures = 0
+		ep.trialAt = time.Time{}
+		return
+	}
+
+	ep.failures++
+	if p.config.FailureThreshold > 0 && ep.failures >= p.config.FailureThreshold {
+		// Opening again after a failed trial restarts the open period
+		ep.openedAt = time.Now()
+	}
+}
+
+// health returns a snapshot of the endpoint health
+func (p *endpointPool) health() []EndpointHealth {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	health := make([]EndpointHealth, len(p.endpoints))
+	for i, ep := range p.endpoints {
+		health[i] = EndpointHealth{
+			BaseURL:             ep.BaseURL,
+			State:               p.state(ep, now),
+			ConsecutiveFailures: ep.failures,
+			ErrorRate:           ep.errorRate,
+			Latency:             ep.latency,
+		}
+	}
+	return health
+}
@@ -0,0 +1,210 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gosticks/openai-responses-api-go/models"
+)
+
+func TestFailoverAzureEndpoints(t *testing.T) {
+	var failing atomic.Int32
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		failing.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer down.Close()
+
+	var gotURL, gotKey string
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotURL = r.URL.String()
+		gotKey = r.Header.Get("api-key")
+		io.WriteString(w, `{"id":"resp_1","status":"completed"}`)
+	}))
+	defer up.Close()
+
+	c := NewClient(
+		WithAPIKey("key"),
+		WithAzure("https://unused.openai.azure.com", "2025-03-01-preview", WithAzureDeployment("gpt-4o")),
+		WithEndpoints(Endpoint{BaseURL: down.URL}, Endpoint{BaseURL: up.URL + "/"}),
+	)
+	if _, err := NewResponses(c).Create(context.Background(), models.ResponseRequest{Model: "m"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if failing.Load() == 0 {
+		t.Error("first endpoint was not tried")
+	}
+	if want := "/openai/deployments/gpt-4o/responses?api-version=2025-03-01-preview"; gotURL != want {
+		t.Errorf("got URL %q, want %q", gotURL, want)
+	}
+	if gotKey != "key" {
+		t.Errorf("got api-key %q, want key", gotKey)
+	}
+
+	health := c.EndpointHealth()
+	if len(health) != 2 || health[0].ConsecutiveFailures == 0 {
+		t.Errorf("failure not recorded against the first endpoint: %+v", health)
+	}
+
+	curl, err := c.Curl(context.Background(), &Request{Method: http.MethodPost, Path: "/responses"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(curl, down.URL+"/openai/deployments/gpt-4o/responses") {
+		t.Errorf("curl does not target the first endpoint: %s", curl)
+	}
+}
+
+func TestFailoverHalfOpenTrial(t *testing.T) {
+	var primaryCalls atomic.Int32
+	var healthy atomic.Bool
+	trialStarted := make(chan struct{})
+	finishTrial := make(chan struct{})
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		primaryCalls.Add(1)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		close(trialStarted)
+		<-finishTrial
+		io.WriteString(w, `{"id":"resp_primary","status":"completed"}`)
+	}))
+	defer primary.Close()
+
+	secondary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"id":"resp_secondary","status":"completed"}`)
+	}))
+	defer secondary.Close()
+
+	const openDuration = 50 * time.Millisecond
+	c := NewClient(
+		WithAPIKey("key"),
+		WithEndpoints(Endpoint{BaseURL: primary.URL}, Endpoint{BaseURL: secondary.URL}),
+		WithCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1, OpenDuration: openDuration}),
+	)
+	responses := NewResponses(c)
+	create := func() string {
+		t.Helper()
+		resp, err := responses.Create(context.Background(), models.ResponseRequest{Model: "m"}, WithMaxRetries(0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.ID
+	}
+	state := func() CircuitState {
+		return c.EndpointHealth()[0].State
+	}
+
+	// A failure opens the circuit, and the open endpoint is skipped
+	if id := create(); id != "resp_secondary" {
+		t.Fatalf("got %s, want failover to the secondary endpoint", id)
+	}
+	if got := state(); got != CircuitOpen {
+		t.Fatalf("got state %s after failure, want %s", got, CircuitOpen)
+	}
+	create()
+	if n := primaryCalls.Load(); n != 1 {
+		t.Fatalf("open endpoint received %d calls, want 1", n)
+	}
+
+	// After the open period a single trial is let through
+	time.Sleep(openDuration)
+	if got := state(); got != CircuitHalfOpen {
+		t.Fatalf("got state %s after the open period, want %s", got, CircuitHalfOpen)
+	}
+	healthy.Store(true)
+	trial := make(chan string)
+	go func() {
+		resp, err := responses.Create(context.Background(), models.ResponseRequest{Model: "m"}, WithMaxRetries(0))
+		if err != nil {
+			t.Error(err)
+			close(trial)
+			return
+		}
+		trial <- resp.ID
+	}()
+	<-trialStarted
+
+	// Calls during the trial still go to the secondary endpoint
+	if id := create(); id != "resp_secondary" {
+		t.Fatalf("got %s during the trial, want the secondary endpoint", id)
+	}
+	close(finishTrial)
+	if id := <-trial; id != "resp_primary" {
+		t.Fatalf("got %s for the trial, want the primary endpoint", id)
+	}
+
+	// A successful trial closes the circuit
+	if got := state(); got != CircuitClosed {
+		t.Fatalf("got state %s after a successful trial, want %s", got, CircuitClosed)
+	}
+	if n := primaryCalls.Load(); n != 2 {
+		t.Errorf("primary endpoint received %d calls, want 2", n)
+	}
+}
+
+func TestFailoverClaimsTrialOnlyWhenTried(t *testing.T) {
+	var primaryHealthy, secondaryHealthy atomic.Bool
+	var secondaryCalls atomic.Int32
+	endpoint := func(healthy *atomic.Bool, calls *atomic.Int32) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			if !healthy.Load() {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			io.WriteString(w, `{"id":"resp_1","status":"completed"}`)
+		}))
+	}
+	primary := endpoint(&primaryHealthy, new(atomic.Int32))
+	defer primary.Close()
+	secondary := endpoint(&secondaryHealthy, &secondaryCalls)
+	defer secondary.Close()
+
+	const openDuration = 50 * time.Millisecond
+	c := NewClient(
+		WithAPIKey("key"),
+		WithEndpoints(Endpoint{BaseURL: primary.URL}, Endpoint{BaseURL: secondary.URL}),
+		WithCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1, OpenDuration: openDuration}),
+	)
+	create := func() error {
+		_, err := NewResponses(c).Create(context.Background(), models.ResponseRequest{Model: "m"}, WithMaxRetries(0))
+		return err
+	}
+
+	// Both endpoints fail and open
+	if err := create(); err == nil {
+		t.Fatal("want an error while both endpoints fail")
+	}
+	time.Sleep(openDuration)
+
+	// The recovered primary serves the call, so the half-open secondary is not probed
+	primaryHealthy.Store(true)
+	secondaryHealthy.Store(true)
+	if err := create(); err != nil {
+		t.Fatal(err)
+	}
+	if n := secondaryCalls.Load(); n != 1 {
+		t.Fatalf("secondary endpoint received %d calls, want 1", n)
+	}
+
+	// The secondary was not claimed, so it is probed as soon as the primary fails again
+	primaryHealthy.Store(false)
+	if err := create(); err != nil {
+		t.Fatal(err)
+	}
+	if n := secondaryCalls.Load(); n != 2 {
+		t.Fatalf("secondary endpoint received %d calls, want 2", n)
+	}
+	if state := c.EndpointHealth()[1].State; state != CircuitClosed {
+		t.Errorf("got secondary state %s after a successful trial, want %s", state, CircuitClosed)
+	}
+}
@@ -0,0 +1,108 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// RequestSigner signs an outgoing request, typically by adding a signature header computed over body.
+// It is called for every attempt, including stream opens, after the authentication headers are set,
+// with the exact bytes sent as the request body. body is nil for requests without a body.
+type RequestSigner func(req *http.Request, body []byte) error
+
+// WithProxy routes all requests through the given HTTP or HTTPS proxy instead of the proxy from the environment
+func WithProxy(proxyURL *url.URL) ClientOption {
+	return func(c *Client) {
+		c.proxy = proxyURL
+		c.transportChanged = true
+	}
+}
+
+// WithRootCAs sets the certificate authorities used to verify the server, e.g. a private gateway CA.
+// See LoadCertPool to load a CA bundle.
+func WithRootCAs(pool *x509.CertPool) ClientOption {
+	return func(c *Client) {
+		c.rootCAs = pool
+		c.transportChanged = true
+	}
+}
+
+// WithClientCertificates sets the certificates presented to the server for mutual TLS
+func WithClientCertificates(certificates ...tls.Certificate) ClientOption {
+	return func(c *Client) {
+		c.certificates = certificates
+		c.transportChanged = true
+	}
+}
+
+// WithRequestSigner sets a signer that is called with every outgoing request and its serialized body
+func WithRequestSigner(signer RequestSigner) ClientOption {
+	return func(c *Client) {
+		c.signer = signer
+	}
+}
+
+// LoadCertPool returns the system certificate pool extended with the PEM encoded certificates in the given files
+func LoadCertPool(files ...string) (*x509.CertPool, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("openai: no certificates found in %s", file)
+		}
+	}
+	return pool, nil
+}
+
+// configureTransport applies the proxy and TLS settings to a copy of the HTTP client, so the HTTP client
+// passed with WithHTTPClient is never modified. The settings only apply if the client uses an
+// *http.Transport, which is the default.
+func (c *Client) configureTransport() {
+	if !c.transportChanged {
+		return
+	}
+	c.transportChanged = false
+	if c.proxy == nil && c.rootCAs == nil && len(c.certificates) == 0 {
+		return
+	}
+
+	base := http.DefaultTransport
+	if c.httpClient.Transport != nil {
+		base = c.httpClient.Transport
+	}
+	transport, ok := base.(*http.Transport)
+	if !ok {
+		return
+	}
+
+	transport = transport.Clone()
+	if c.proxy != nil {
+		transport.Proxy = http.ProxyURL(c.proxy)
+	}
+	if c.rootCAs != nil || len(c.certificates) > 0 {
+		if transport.TLSClientConfig == nil {
+			transport.TLSClientConfig = &tls.Config{}
+		}
+		if c.rootCAs != nil {
+			transport.TLSClientConfig.RootCAs = c.rootCAs
+		}
+		if len(c.certificates) > 0 {
+			transport.TLSClientConfig.Certificates = c.certificates
+		}
+	}
+
+	httpClient := *c.httpClient
+	httpClient.Transport = transport
+	c.httpClient = &httpClient
+}
@@ -0,0 +1,278 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// HARRecorder records the HTTP traffic of a client in the HTTP Archive (HAR) format for offline
+// inspection. Every attempt is recorded, including retries and failed connections, and streamed
+// server-sent events bodies are captured in full once the stream is closed. Credentials are redacted.
+// Recorded traffic is kept in memory, so a recorder is meant for debugging. It is safe for concurrent use.
+type HARRecorder struct {
+	mu      sync.Mutex
+	entries []harEntry
+}
+
+// NewHARRecorder creates an empty HAR recorder
+func NewHARRecorder() *HARRecorder {
+	return &HARRecorder{}
+}
+
+// WithHARRecorder records all HTTP traffic of the client with the given recorder
+func WithHARRecorder(recorder *HARRecorder) ClientOption {
+	return func(c *Client) {
+		c.har = recorder
+	}
+}
+
+// Len returns the number of recorded entries
+func (h *HARRecorder) Len() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.entries)
+}
+
+// Reset discards the recorded entries
+func (h *HARRecorder) Reset() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.entries = nil
+}
+
+// WriteTo writes the recorded traffic as a HAR document to w
+func (h *HARRecorder) WriteTo(w io.Writer) (int64, error) {
+	h.mu.Lock()
+	entries := append([]harEntry{}, h.entries...)
+	h.mu.Unlock()
+
+	var doc struct {
+		Log harLog `json:"log"`
+	}
+	doc.Log = harLog{
+		Version: "1.2",
+		Creator: harCreator{Name: "openai-responses-api-go", Version: DefaultUserAgent},
+		Entries: entries,
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(data)
+	return int64(n), err
+}
+
+// Save writes the recorded traffic as a HAR file
+func (h *HARRecorder) Save(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := h.WriteTo(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// record records an attempt. If the attempt produced a response, its body is captured as it is read
+// and the entry is added once the body is closed.
+func (h *HARRecorder) record(req *http.Request, start time.Time, resp *http.Response, err error) {
+	entry := harEntry{
+		StartedDateTime: start.Format(time.RFC3339Nano),
+		Request: harRequest{
+			Method:      req.Method,
+			URL:         req.URL.String(),
+			HTTPVersion: req.Proto,
+			Cookies:     []harNameValue{},
+			Headers:     harHeaders(redactHeader(req.Header)),
+			QueryString: harQuery(req),
+			HeadersSize: -1,
+			BodySize:    -1,
+		},
+		Cache: struct{}{},
+	}
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			data, _ := io.ReadAll(body)
+			entry.Request.BodySize = len(data)
+			entry.Request.PostData = &harPostData{
+				MimeType: req.Header.Get("Content-Type"),
+				Text:     redactString(string(data)),
+			}
+		}
+	}
+
+	if err != nil {
+		entry.Time = msSince(start)
+		entry.Response = harResponse{
+			Cookies:     []harNameValue{},
+			Headers:     []harNameValue{},
+			HeadersSize: -1,
+			BodySize:    -1,
+			Error:       redactString(err.Error()),
+		}
+		entry.Timings = harTimings{Wait: entry.Time}
+		h.add(entry)
+		return
+	}
+
+	wait := msSince(start)
+	entry.Response = harResponse{
+		Status:      resp.StatusCode,
+		StatusText:  http.StatusText(resp.StatusCode),
+		HTTPVersion: resp.Proto,
+		Cookies:     []harNameValue{},
+		Headers:     harHeaders(redactHeader(resp.Header)),
+		Content:     harContent{MimeType: resp.Header.Get("Content-Type")},
+		HeadersSize: -1,
+	}
+	resp.Body = &harBody{ReadCloser: resp.Body, recorder: h, entry: entry, start: start, wait: wait}
+}
+
+// add adds a completed entry
+func (h *HARRecorder) add(entry harEntry) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.entries = append(h.entries, entry)
+}
+
+// harBody captures a response body and adds the entry once the body is closed
+type harBody struct {
+	io.ReadCloser
+	recorder *HARRecorder
+	entry    harEntry
+	start    time.Time
+	wait     float64
+	once     sync.Once
+
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+// Read reads from the body and captures the data read
+func (b *harBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.mu.Lock()
+	b.buf.Write(p[:n])
+	b.mu.Unlock()
+	return n, err
+}
+
+// Close closes the body and adds the entry
+func (b *harBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.entry.Time = msSince(b.start)
+		b.entry.Timings = harTimings{Wait: b.wait, Receive: b.entry.Time - b.wait}
+		b.entry.Response.BodySize = b.buf.Len()
+		b.entry.Response.Content.Size = b.buf.Len()
+		b.entry.Response.Content.Text = b.buf.String()
+		b.recorder.add(b.entry)
+	})
+	return err
+}
+
+// msSince returns the milliseconds elapsed since start
+func msSince(start time.Time) float64 {
+	return float64(time.Since(start).Microseconds()) / 1000
+}
+
+// harHeaders converts HTTP headers into HAR name-value pairs
+func harHeaders(header http.Header) []harNameValue {
+	pairs := []harNameValue{}
+	for name, values := range header {
+		for _, value := range values {
+			pairs = append(pairs, harNameValue{Name: name, Value: value})
+		}
+	}
+	return pairs
+}
+
+// harQuery converts the query of a request URL into HAR name-value pairs
+func harQuery(req *http.Request) []harNameValue {
+	pairs := []harNameValue{}
+	for name, values := range req.URL.Query() {
+		for _, value := range values {
+			pairs = append(pairs, harNameValue{Name: name, Value: value})
+		}
+	}
+	return pairs
+}
+
+// HAR 1.2 document types, see http://www.softwareishard.com/blog/har-12-spec/
+
+type harLog struct {
+	Version string     `json:"version"`
+	Creator harCreator `json:"creator"`
+	Entries []harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+	Error       string         `json:"_error,omitempty"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+}
+
+type harTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
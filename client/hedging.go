@@ -0,0 +1,160 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gosticks/openai-responses-api-go/models"
+)
+
+// HedgePolicy configures hedged requests. When an attempt has not returned after Delay, another
+// identical attempt is sent, the first successful attempt wins and the others are canceled.
+type HedgePolicy struct {
+	// Delay is the time to wait for an attempt before sending the next one
+	Delay time.Duration
+	// MaxAttempts is the maximum number of concurrent attempts, including the first one. Defaults to 2.
+	MaxAttempts int
+	// AllowSideEffects hedges calls that may have side effects, such as creating a stored response
+	// without an idempotency key. Every attempt may then create its own response.
+	AllowSideEffects bool
+}
+
+// WithHedging enables hedged requests for a non-streaming call. Calls with side effects are only hedged
+// if they carry an idempotency key or the policy allows side effects, otherwise they are sent once.
+// The winning attempt is reported in the response metadata.
+func WithHedging(policy HedgePolicy) RequestOption {
+	return func(r *Request) {
+		r.Hedge = &policy
+	}
+}
+
+// hedgeable reports whether the request may be sent several times concurrently
+func hedgeable(req *Request) bool {
+	if req.Stream {
+		return false
+	}
+	if req.Hedge.AllowSideEffects || req.Header.Get("Idempotency-Key") != "" {
+		return true
+	}
+	// Creating a response stores it unless the request explicitly sets store to false
+	switch body := req.Body.(type) {
+	case models.ResponseRequest:
+		return isUnstored(body)
+	case *models.ResponseRequest:
+		return body != nil && isUnstored(*body)
+	}
+	return req.Method == http.MethodGet || req.Method == http.MethodHead
+}
+
+// isUnstored reports whether the request explicitly disables storing the response
+func isUnstored(request models.ResponseRequest) bool {
+	store, ok := request.Store.Get()
+	return ok && !store
+}
+
+// hedgeResult is the outcome of a single hedged attempt
+type hedgeResult struct {
+	attempt int
+	resp    *Response
+	err     error
+}
+
+// hedge is the pipeline stage that sends hedged attempts of a request and returns the first success.
+// Attempts that fail with a retryable error immediately make room for the next attempt.
+func (c *Client) hedge(next Handler) Handler {
+	return func(ctx context.Context, req *Request) (*Response, error) {
+		if req.Hedge == nil || !hedgeable(req) {
+			return next(ctx, req)
+		}
+
+		maxAttempts := req.Hedge.MaxAttempts
+		if maxAttempts <= 0 {
+			maxAttempts = 2
+		}
+
+		results := make(chan hedgeResult, maxAttempts)
+		cancels := make([]context.CancelFunc, 0, maxAttempts)
+		launch := func() {
+			attemptCtx, cancel := context.WithCancel(ctx)
+			cancels = append(cancels, cancel)
+			attempt := len(cancels)
+			go func() {
+				resp, err := next(attemptCtx, req)
+				results <- hedgeResult{attempt: attempt, resp: resp, err: err}
+			}()
+		}
+
+		// abandon cancels all attempts except the winner and discards their results
+		abandon := func(winner, pending int) {
+			for i, cancel := range cancels {
+				if i+1 != winner {
+					cancel()
+				}
+			}
+			go func() {
+				for ; pending > 0; pending-- {
+					if r := <-results; r.err == nil {
+						r.resp.HTTP.Body.Close()
+					}
+				}
+			}()
+		}
+
+		launch()
+		pending := 1
+		timer := time.NewTimer(req.Hedge.Delay)
+		defer timer.Stop()
+
+		var firstErr error
+		for pending > 0 {
+			var hedgeTimer <-chan time.Time
+			if len(cancels) < maxAttempts {
+				hedgeTimer = timer.C
+			}
+
+			select {
+			case <-hedgeTimer:
+				launch()
+				pending++
+				timer.Reset(req.Hedge.Delay)
+
+			case r := <-results:
+				pending--
+				if r.err == nil {
+					abandon(r.attempt, pending)
+					c.recordHedge(ctx, r.attempt, len(cancels))
+					if r.resp.Metadata != nil {
+						r.resp.Metadata.HedgeAttempt = r.attempt
+						r.resp.Metadata.HedgeAttempts = len(cancels)
+					}
+					r.resp.HTTP.Body = &cancelOnClose{ReadCloser: r.resp.HTTP.Body, cancel: cancels[r.attempt-1]}
+					return r.resp, nil
+				}
+
+				if firstErr == nil {
+					firstErr = r.err
+				}
+				if !IsRetryable(r.err) || ctx.Err() != nil {
+					abandon(0, pending)
+					return nil, r.err
+				}
+				if len(cancels) < maxAttempts {
+					launch()
+					pending++
+					timer.Reset(req.Hedge.Delay)
+				}
+			}
+		}
+
+		abandon(0, 0)
+		return nil, firstErr
+	}
+}
+
+// recordHedge records which attempt won a hedged call
+func (c *Client) recordHedge(ctx context.Context, winner, attempts int) {
+	if c.meter != nil {
+		c.meter.RecordHistogram(ctx, MetricHedgeWinner, float64(winner), Attr(AttrHedgeAttempts, attempts))
+	}
+}
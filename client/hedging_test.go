@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gosticks/openai-responses-api-go/models"
+)
+
+func TestHedgeWinnerCancelsLoser(t *testing.T) {
+	var requests atomic.Int32
+	loserCanceled := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			// The first attempt hangs until the client cancels it. The server only notices the
+			// canceled connection once the body is read.
+			io.Copy(io.Discard, r.Body)
+			<-r.Context().Done()
+			close(loserCanceled)
+			return
+		}
+		io.WriteString(w, `{"id":"resp_2","status":"completed"}`)
+	}))
+	defer server.Close()
+
+	c := NewClient(WithAPIKey("key"), WithBaseURL(server.URL))
+	resp, err := NewResponses(c).Create(
+		context.Background(),
+		models.ResponseRequest{Model: "m"},
+		WithIdempotencyKey("key-1"),
+		WithHedging(HedgePolicy{Delay: 10 * time.Millisecond}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.ID != "resp_2" || resp.Metadata.HedgeAttempt != 2 || resp.Metadata.HedgeAttempts != 2 {
+		t.Errorf("got response %s won by attempt %d of %d, want resp_2 won by attempt 2 of 2",
+			resp.ID, resp.Metadata.HedgeAttempt, resp.Metadata.HedgeAttempts)
+	}
+
+	select {
+	case <-loserCanceled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("losing attempt was not canceled")
+	}
+}
+
+func TestHedgeSkipsStoredCreate(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		time.Sleep(50 * time.Millisecond)
+		io.WriteString(w, `{"id":"resp_1","status":"completed"}`)
+	}))
+	defer server.Close()
+
+	c := NewClient(WithAPIKey("key"), WithBaseURL(server.URL))
+	resp, err := NewResponses(c).Create(
+		context.Background(),
+		models.ResponseRequest{Model: "m"},
+		WithHedging(HedgePolicy{Delay: time.Millisecond}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := requests.Load(); n != 1 || resp.Metadata.HedgeAttempts != 0 {
+		t.Errorf("got %d requests and %d hedge attempts, want a single unhedged request", n, resp.Metadata.HedgeAttempts)
+	}
+}
+
+func TestHedgeUnstoredCreate(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		time.Sleep(50 * time.Millisecond)
+		io.WriteString(w, `{"id":"resp_1","status":"completed"}`)
+	}))
+	defer server.Close()
+
+	c := NewClient(WithAPIKey("key"), WithBaseURL(server.URL))
+	resp, err := NewResponses(c).Create(
+		context.Background(),
+		models.ResponseRequest{Model: "m", Store: models.Bool(false)},
+		WithHedging(HedgePolicy{Delay: time.Millisecond}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := requests.Load(); n != 2 || resp.Metadata.HedgeAttempts != 2 {
+		t.Errorf("got %d requests and %d hedge attempts, want a hedged request", n, resp.Metadata.HedgeAttempts)
+	}
+}
@@ -0,0 +1,200 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gosticks/openai-responses-api-go/models"
+)
+
+// redacted replaces secrets and redacted values in logs
+const redacted = "[REDACTED]"
+
+// apiKeyPattern matches OpenAI style API keys embedded in logged bodies
+var apiKeyPattern = regexp.MustCompile(`sk-[A-Za-z0-9_\-]{8,}`)
+
+// sensitiveHeaders are never logged in clear text
+var sensitiveHeaders = []string{"Authorization", "Api-Key", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// LogConfig configures what the client logs
+type LogConfig struct {
+	// Level is the level for completed calls and streams
+	Level slog.Level
+	// ErrorLevel is the level for failed calls and streams
+	ErrorLevel slog.Level
+	// LogBodies enables logging of request bodies and headers at debug level
+	LogBodies bool
+	// RedactPaths are dot-separated JSON paths redacted from logged bodies, e.g. "input.content".
+	// Arrays along the path are traversed element by element.
+	RedactPaths []string
+}
+
+// DefaultLogConfig returns the default log configuration
+func DefaultLogConfig() LogConfig {
+	return LogConfig{
+		Level:      slog.LevelInfo,
+		ErrorLevel: slog.LevelWarn,
+	}
+}
+
+// WithLogger sets the structured logger for the client. Secrets are always redacted.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// WithLogConfig sets the log configuration for the client
+func WithLogConfig(config LogConfig) ClientOption {
+	return func(c *Client) {
+		c.logConfig = config
+	}
+}
+
+// logging is the middleware that logs every call once it completes or fails
+func (c *Client) logging(next Handler) Handler {
+	return func(ctx context.Context, req *Request) (*Response, error) {
+		attrs := []slog.Attr{
+			slog.String("method", req.Method),
+			slog.String("path", req.Path),
+			slog.Bool("stream", req.Stream),
+		}
+		if model := requestModel(req.Body); model != "" {
+			attrs = append(attrs, slog.String("model", model))
+		}
+		if req.Tenant != "" {
+			attrs = append(attrs, slog.String("tenant", req.Tenant))
+		}
+
+		if c.logConfig.LogBodies && c.logger.Enabled(ctx, slog.LevelDebug) {
+			c.logger.LogAttrs(ctx, slog.LevelDebug, "openai request",
+				append(attrs,
+					slog.Any("header", redactHeader(req.Header)),
+					slog.String("body", c.redactBody(req.Body)),
+				)...)
+		}
+
+		start := time.Now()
+		resp, err := next(ctx, req)
+		attrs = append(attrs, slog.Duration("latency", time.Since(start)))
+		if err != nil {
+			c.logger.LogAttrs(ctx, c.logConfig.ErrorLevel, "openai request failed",
+				append(attrs, slog.String("error", redactString(err.Error())))...)
+			return nil, err
+		}
+
+		attrs = append(attrs, slog.Int("status", resp.HTTP.StatusCode))
+		if resp.Metadata != nil && resp.Metadata.RequestID != "" {
+			attrs = append(attrs, slog.String("request_id", resp.Metadata.RequestID))
+		}
+		c.logger.LogAttrs(ctx, c.logConfig.Level, "openai request completed", attrs...)
+		return resp, nil
+	}
+}
+
+// logUsage logs the token usage of a completed response
+func (c *Client) logUsage(ctx context.Context, response *models.ResponseResponse) {
+	if c.logger == nil {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("response_id", response.ID),
+		slog.String("model", response.Model),
+	}
+	c.logger.LogAttrs(ctx, c.logConfig.Level, "openai response usage", append(attrs, usageAttrs(response.Usage)...)...)
+}
+
+// usageAttrs converts token usage into log attributes
+func usageAttrs(usage *models.Usage) []slog.Attr {
+	if usage == nil {
+		return nil
+	}
+	attrs := []slog.Attr{
+		slog.Int("input_tokens", usage.InputTokens),
+		slog.Int("output_tokens", usage.OutputTokens),
+		slog.Int("total_tokens", usage.TotalTokens),
+	}
+	if reasoning := usage.ReasoningTokens(); reasoning > 0 {
+		attrs = append(attrs, slog.Int("reasoning_tokens", reasoning))
+	}
+	return attrs
+}
+
+// requestModel returns the model of a request body, if any
+func requestModel(body interface{}) string {
+	switch r := body.(type) {
+	case models.ResponseRequest:
+		return r.Model
+	case *models.ResponseRequest:
+		return r.Model
+	}
+	return ""
+}
+
+// redactHeader returns a copy of header with credentials masked
+func redactHeader(header http.Header) http.Header {
+	header = header.Clone()
+	for _, key := range sensitiveHeaders {
+		if header.Get(key) != "" {
+			header.Set(key, redacted)
+		}
+	}
+	return header
+}
+
+// redactString masks API keys in s
+func redactString(s string) string {
+	return apiKeyPattern.ReplaceAllString(s, redacted)
+}
+
+// redactBody serializes a request body for logging with the configured paths and API keys redacted
+func (c *Client) redactBody(body interface{}) string {
+	if body == nil {
+		return ""
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return ""
+	}
+
+	if len(c.logConfig.RedactPaths) > 0 {
+		var value interface{}
+		if err := json.Unmarshal(data, &value); err == nil {
+			for _, path := range c.logConfig.RedactPaths {
+				redactPath(value, strings.Split(path, "."))
+			}
+			if redactedData, err := json.Marshal(value); err == nil {
+				data = redactedData
+			}
+		}
+	}
+
+	return redactString(string(data))
+}
+
+// redactPath replaces the value at path in a decoded JSON value, traversing arrays element by element
+func redactPath(value interface{}, path []string) {
+	switch v := value.(type) {
+	case []interface{}:
+		for _, item := range v {
+			redactPath(item, path)
+		}
+	case map[string]interface{}:
+		child, ok := v[path[0]]
+		if !ok {
+			return
+		}
+		if len(path) == 1 {
+			v[path[0]] = redacted
+			return
+		}
+		redactPath(child, path[1:])
+	}
+}
@@ -0,0 +1,38 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gosticks/openai-responses-api-go/models"
+)
+
+func TestLogUsage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"id":"resp_1","usage":{"input_tokens":11,"output_tokens":7,"total_tokens":18,"output_tokens_details":{"reasoning_tokens":3}}}`)
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	c := NewClient(WithAPIKey("key"), WithBaseURL(server.URL), WithLogger(logger))
+	resp, err := NewResponses(c).Create(context.Background(), models.ResponseRequest{Model: "m"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.Usage == nil || resp.Usage.InputTokens != 11 || resp.Usage.PromptTokens != 11 || resp.Usage.ReasoningTokens() != 3 {
+		t.Errorf("got usage %+v", resp.Usage)
+	}
+	for _, want := range []string{`"input_tokens":11`, `"output_tokens":7`, `"reasoning_tokens":3`} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("log does not contain %s: %s", want, buf.String())
+		}
+	}
+}
@@ -0,0 +1,316 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gosticks/openai-responses-api-go/models"
+)
+
+// Request describes an outgoing API call as seen by middleware
+type Request struct {
+	// Method is the HTTP method of the request
+	Method string
+	// Path is the endpoint path relative to the base URL, e.g. "/responses"
+	Path string
+	// Body is the value serialized as the JSON request body, such as a models.ResponseRequest
+	Body interface{}
+	// Stream indicates whether the request opens a server-sent events stream
+	Stream bool
+	// Header holds the headers sent with every attempt of the request
+	Header http.Header
+	// Query holds extra query parameters added to the request URL
+	Query url.Values
+	// Timeout is the timeout for the call, or 0 to rely on the context and HTTP client
+	Timeout time.Duration
+	// MaxAttempts overrides the maximum number of attempts of the client retry policy when positive
+	MaxAttempts int
+	// APIKey overrides the client API key when set
+	APIKey string
+	// Organization overrides the client organization ID when set
+	Organization string
+	// Project overrides the client project ID when set
+	Project string
+	// Priority overrides the priority carried by the context when queuing for the concurrency limiter
+	Priority *Priority
+	// Hedge enables hedged requests for the call when set
+	Hedge *HedgePolicy
+	// Tenant is the ID of the tenant the call is made for, selected by a request option or the context
+	Tenant string
+
+	// connecting is called before every attempt is sent, after the call obtained its concurrency slot
+	// and rate limit budget
+	connecting func()
+}
+
+// Response describes the result of an API call as seen by middleware
+type Response struct {
+	// Request is the request that produced the response
+	Request *Request
+	// HTTP is the underlying HTTP response. Its body is consumed by the client after
+	// the middleware chain returns, so middleware must not read it.
+	HTTP *http.Response
+	// RateLimit is the rate limit state reported by the API
+	RateLimit *models.RateLimitInfo
+	// Metadata holds the HTTP response details such as the request ID
+	Metadata *models.ResponseMetadata
+	// Duration is the time spent sending the request and receiving the response headers,
+	// including retries
+	Duration time.Duration
+}
+
+// Handler sends a request through the client pipeline.
+// It returns an error for transport failures and for error responses from the API.
+type Handler func(ctx context.Context, req *Request) (*Response, error)
+
+// Middleware wraps a Handler to add cross-cutting behavior to every API call
+type Middleware func(next Handler) Handler
+
+// WithMiddleware appends middleware to the client pipeline. The first middleware is the outermost
+// one and sees every call, both regular and streaming, exactly once regardless of retries.
+func WithMiddleware(middleware ...Middleware) ClientOption {
+	return func(c *Client) {
+		c.middleware = append(c.middleware, middleware...)
+	}
+}
+
+// send runs a request through the middleware chain and the transport.
+// Request options are applied on top of the client defaults before the chain runs.
+func (c *Client) send(ctx context.Context, req *Request, options ...RequestOption) (*Response, error) {
+	if err := c.prepare(ctx, req, options...); err != nil {
+		return nil, err
+	}
+
+	handler := c.transport
+	if req.Hedge != nil {
+		handler = c.hedge(handler)
+	}
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		handler = c.middleware[i](handler)
+	}
+	if c.logger != nil {
+		handler = c.logging(handler)
+	}
+	if c.concurrencyLimiter != nil {
+		handler = c.limit(handler)
+	}
+	return c.track(withTimeout(handler))(ctx, req)
+}
+
+// prepare sets the client defaults of a request and applies the request options and the selected tenant
+func (c *Client) prepare(ctx context.Context, req *Request, options ...RequestOption) error {
+	if req.Header == nil {
+		req.Header = make(http.Header)
+	}
+
+	// Set default headers so that middleware can inspect and override them
+	req.Header.Set("Content-Type", "application/json")
+	if req.Stream {
+		req.Header.Set("Accept", "text/event-stream")
+	} else {
+		req.Header.Set("Accept", "application/json")
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+	if c.organization != "" {
+		req.Header.Set("OpenAI-Organization", c.organization)
+	}
+	if c.project != "" {
+		req.Header.Set("OpenAI-Project", c.project)
+	}
+
+	// Non-streaming calls are bounded by the client timeout, streams by the stream timeouts
+	if !req.Stream {
+		req.Timeout = c.timeout
+	}
+
+	// Apply request options
+	for _, option := range options {
+		option(req)
+	}
+	if err := c.applyTenant(ctx, req); err != nil {
+		return err
+	}
+
+	if req.Organization != "" {
+		req.Header.Set("OpenAI-Organization", req.Organization)
+	}
+	if req.Project != "" {
+		req.Header.Set("OpenAI-Project", req.Project)
+	}
+	return nil
+}
+
+// authorize sets the authentication headers of a request attempt unless they were set explicitly
+// by a request option or middleware. It returns the credential obtained from the credential
+// provider, if one was used.
+func (c *Client) authorize(ctx context.Context, req *Request, header http.Header, provider CredentialProvider) (string, error) {
+	if header.Get("Authorization") != "" || header.Get("api-key") != "" {
+		return "", nil
+	}
+
+	var credential string
+	apiKey := req.APIKey
+	if apiKey == "" && provider != nil && (c.azure == nil || c.azure.TokenProvider == nil) {
+		var err error
+		if credential, err = provider.Credential(ctx); err != nil {
+			return "", err
+		}
+		apiKey = credential
+	}
+	if apiKey == "" {
+		apiKey = c.apiKey
+	}
+
+	if c.azure != nil {
+		if req.APIKey != "" {
+			header.Set("api-key", req.APIKey)
+			return "", nil
+		}
+		return credential, c.azure.authorize(ctx, header, apiKey)
+	}
+	header.Set("Authorization", "Bearer "+apiKey)
+	return credential, nil
+}
+
+// reportCredential tells the credential provider how a request made with credential ended
+func reportCredential(provider CredentialProvider, credential string, resp *http.Response) {
+	if reporter, ok := provider.(CredentialReporter); ok && credential != "" && resp != nil {
+		reporter.Report(credential, resp.StatusCode)
+	}
+}
+
+// defaultBaseURL returns the base URL requests are sent to when no endpoints are configured,
+// which is the resource endpoint for Azure OpenAI
+func (c *Client) defaultBaseURL() string {
+	if c.azure != nil {
+		return c.azure.Endpoint
+	}
+	return c.baseURL
+}
+
+// url builds the URL for the given request against baseURL
+func (c *Client) url(baseURL string, req *Request) (*url.URL, error) {
+	var u *url.URL
+	var err error
+	if c.azure != nil {
+		u, err = c.azure.url(baseURL, req.Path)
+	} else {
+		u, err = url.Parse(baseURL + req.Path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.Query) > 0 {
+		query := u.Query()
+		for key, values := range req.Query {
+			query[key] = append(query[key], values...)
+		}
+		u.RawQuery = query.Encode()
+	}
+	return u, nil
+}
+
+// transport is the innermost handler of the pipeline. It serializes the request body,
+// sends the request with retries, rate limiting and endpoint failover, and decodes error responses.
+func (c *Client) transport(ctx context.Context, req *Request) (*Response, error) {
+	// Create the request body
+	var jsonBody []byte
+	if req.Body != nil {
+		var err error
+		jsonBody, err = json.Marshal(req.Body)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	start := time.Now()
+	var resp *http.Response
+	var err error
+	if c.pool == nil {
+		resp, err = c.attempt(ctx, req, jsonBody, c.defaultBaseURL(), c.credentials)
+	} else {
+		resp, err = c.failover(ctx, req, jsonBody)
+	}
+	if err != nil {
+		return nil, wrapTransportError(err)
+	}
+
+	duration := time.Since(start)
+
+	// Check for errors
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		return nil, decodeError(resp)
+	}
+
+	return &Response{
+		Request:   req,
+		HTTP:      resp,
+		RateLimit: parseRateLimitInfo(resp.Header),
+		Metadata:  newResponseMetadata(resp, duration),
+		Duration:  duration,
+	}, nil
+}
+
+// attempt sends the request to baseURL, retrying according to the retry policy
+func (c *Client) attempt(ctx context.Context, req *Request, jsonBody []byte, baseURL string, provider CredentialProvider) (*http.Response, error) {
+	// Construct the URL
+	u, err := c.url(baseURL, req)
+	if err != nil {
+		return nil, err
+	}
+	c.attempting(ctx, u)
+
+	// Make the request, rebuilding and authenticating it for every attempt so the body can be
+	// replayed and credentials can rotate between attempts
+	var credential string
+	return c.do(ctx, c.retryPolicy(req), estimateTokens(jsonBody, req.Body), func() (*http.Request, error) {
+		if req.connecting != nil {
+			req.connecting()
+		}
+
+		var reqBody io.Reader
+		if jsonBody != nil {
+			reqBody = bytes.NewReader(jsonBody)
+		}
+
+		httpReq, err := http.NewRequestWithContext(ctx, req.Method, u.String(), reqBody)
+		if err != nil {
+			return nil, err
+		}
+		httpReq.Header = req.Header.Clone()
+		credential, err = c.authorize(ctx, req, httpReq.Header, provider)
+		if err != nil {
+			return nil, err
+		}
+		if c.signer != nil {
+			if err := c.signer(httpReq, jsonBody); err != nil {
+				return nil, err
+			}
+		}
+		return httpReq, nil
+	}, func(resp *http.Response) {
+		reportCredential(provider, credential, resp)
+	})
+}
+
+// newResponseMetadata collects the HTTP level details of a response
+func newResponseMetadata(resp *http.Response, latency time.Duration) *models.ResponseMetadata {
+	metadata := &models.ResponseMetadata{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		RequestID:  resp.Header.Get("x-request-id"),
+		Latency:    latency,
+	}
+	if ms, err := strconv.ParseFloat(resp.Header.Get("openai-processing-ms"), 64); err == nil {
+		metadata.ProcessingTime = time.Duration(ms * float64(time.Millisecond))
+	}
+	return metadata
+}
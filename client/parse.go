@@ -0,0 +1,45 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/gosticks/openai-responses-api-go/models"
+)
+
+// Parse creates a response whose output follows a strict JSON schema derived from T, and decodes the
+// output into a T. See models.JSONSchemaFor for how the schema is derived. Other text settings of the
+// request are kept, and streaming is disabled. If the model refuses, a RefusalError is returned.
+func Parse[T any](ctx context.Context, r *Responses, request models.ResponseRequest, options ...RequestOption) (*models.ParsedResponse[T], error) {
+	schema, err := models.JSONSchemaFor[T]()
+	if err != nil {
+		return nil, err
+	}
+
+	text := models.TextConfig{}
+	if request.Text != nil {
+		text = *request.Text
+	}
+	text.Format = models.JSONSchemaFormat(models.SchemaName[T](), schema)
+	request.Text = &text
+	request.Stream = false
+
+	response, err := r.Create(ctx, request, options...)
+	if err != nil {
+		return nil, err
+	}
+	if refusal := response.Output.Refusal(); refusal != "" {
+		return nil, &RefusalError{Refusal: refusal, Response: response}
+	}
+
+	parsed := &models.ParsedResponse[T]{ResponseResponse: response}
+	if err := json.Unmarshal([]byte(response.GetOutputText()), &parsed.Parsed); err != nil {
+		if response.Status == "incomplete" {
+			// The output was cut off, e.g. by MaxOutputTokens
+			return nil, fmt.Errorf("openai: decoding structured output of incomplete response: %w", err)
+		}
+		return nil, fmt.Errorf("openai: decoding structured output: %w", err)
+	}
+	return parsed, nil
+}
@@ -0,0 +1,208 @@
+package client
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gosticks/openai-responses-api-go/models"
+)
+
+// charsPerToken is the rough number of request body bytes per token used to estimate request size
+const charsPerToken = 4
+
+// parseRateLimitInfo extracts the rate limit headers from an API response.
+// It returns nil if the response carries no rate limit headers.
+func parseRateLimitInfo(header http.Header) *models.RateLimitInfo {
+	if header == nil || header.Get("x-ratelimit-remaining-requests") == "" && header.Get("x-ratelimit-remaining-tokens") == "" {
+		return nil
+	}
+
+	return &models.RateLimitInfo{
+		LimitRequests:     headerInt(header, "x-ratelimit-limit-requests"),
+		LimitTokens:       headerInt(header, "x-ratelimit-limit-tokens"),
+		RemainingRequests: headerInt(header, "x-ratelimit-remaining-requests"),
+		RemainingTokens:   headerInt(header, "x-ratelimit-remaining-tokens"),
+		ResetRequests:     headerDuration(header, "x-ratelimit-reset-requests"),
+		ResetTokens:       headerDuration(header, "x-ratelimit-reset-tokens"),
+	}
+}
+
+// headerInt parses an integer header, returning 0 if it is missing or malformed
+func headerInt(header http.Header, key string) int {
+	n, _ := strconv.Atoi(header.Get(key))
+	return n
+}
+
+// headerDuration parses a duration header such as "1s", "6m0s" or "20ms".
+// Plain numbers are interpreted as seconds.
+func headerDuration(header http.Header, key string) time.Duration {
+	v := header.Get(key)
+	if v == "" {
+		return 0
+	}
+	if d, err := time.ParseDuration(v); err == nil {
+		return d
+	}
+	if secs, err := strconv.ParseFloat(v, 64); err == nil {
+		return time.Duration(secs * float64(time.Second))
+	}
+	return 0
+}
+
+// RateLimiter throttles outgoing requests on the client side using token buckets for
+// requests per minute and tokens per minute. It is safe for concurrent use.
+type RateLimiter struct {
+	mu       sync.Mutex
+	requests *bucket
+	tokens   *bucket
+}
+
+// NewRateLimiter creates a rate limiter allowing the given number of requests and tokens per minute.
+// A limit of 0 disables the corresponding bucket.
+func NewRateLimiter(requestsPerMinute, tokensPerMinute int) *RateLimiter {
+	now := time.Now()
+	return &RateLimiter{
+		requests: newBucket(requestsPerMinute, now),
+		tokens:   newBucket(tokensPerMinute, now),
+	}
+}
+
+// WithRateLimiter sets a client-wide rate limiter that delays outgoing requests
+func WithRateLimiter(limiter *RateLimiter) ClientOption {
+	return func(c *Client) {
+		c.rateLimiter = limiter
+	}
+}
+
+// Wait blocks until a request estimated to use the given number of tokens may be sent,
+// or until the context is done
+func (l *RateLimiter) Wait(ctx context.Context, tokens int) error {
+	for {
+		l.mu.Lock()
+		now := time.Now()
+		delay := max(l.requests.reserve(1, now), l.tokens.reserve(float64(tokens), now))
+		if delay <= 0 {
+			l.requests.take(1)
+			l.tokens.take(float64(tokens))
+		}
+		l.mu.Unlock()
+
+		if delay <= 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Observe adjusts the limiter to the rate limit state reported by the API
+func (l *RateLimiter) Observe(info *models.RateLimitInfo) {
+	if info == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Only trust buckets for which the server reported a limit
+	now := time.Now()
+	if info.LimitRequests > 0 {
+		l.requests.observe(info.RemainingRequests, info.ResetRequests, now)
+	}
+	if info.LimitTokens > 0 {
+		l.tokens.observe(info.RemainingTokens, info.ResetTokens, now)
+	}
+}
+
+// bucket is a token bucket refilled continuously over a one minute window
+type bucket struct {
+	capacity  float64
+	available float64
+	rate      float64 // refill rate per second
+	last      time.Time
+	blocked   time.Time // no capacity is handed out before this time
+}
+
+// newBucket creates a full bucket for the given per-minute limit, or nil if the limit is disabled
+func newBucket(perMinute int, now time.Time) *bucket {
+	if perMinute <= 0 {
+		return nil
+	}
+	return &bucket{
+		capacity:  float64(perMinute),
+		available: float64(perMinute),
+		rate:      float64(perMinute) / 60,
+		last:      now,
+	}
+}
+
+// refill adds the capacity accumulated since the last refill
+func (b *bucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.available = math.Min(b.capacity, b.available+elapsed*b.rate)
+		b.last = now
+	}
+}
+
+// reserve returns how long to wait until n units are available
+func (b *bucket) reserve(n float64, now time.Time) time.Duration {
+	if b == nil {
+		return 0
+	}
+	if now.Before(b.blocked) {
+		return b.blocked.Sub(now)
+	}
+
+	b.refill(now)
+
+	// Requests larger than the bucket would never fit, so only wait for a full bucket
+	n = math.Min(n, b.capacity)
+	if b.available >= n {
+		return 0
+	}
+	return time.Duration((n - b.available) / b.rate * float64(time.Second))
+}
+
+// take removes n units from the bucket
+func (b *bucket) take(n float64) {
+	if b == nil {
+		return
+	}
+	b.available -= math.Min(n, b.capacity)
+}
+
+// observe lowers the available capacity to what the server reports as remaining
+func (b *bucket) observe(remaining int, reset time.Duration, now time.Time) {
+	if b == nil {
+		return
+	}
+
+	b.refill(now)
+	b.available = math.Min(b.available, float64(remaining))
+	if remaining <= 0 && reset > 0 {
+		b.blocked = now.Add(reset)
+	}
+}
+
+// estimateTokens roughly estimates the number of tokens a request consumes from its serialized
+// body, including the requested output budget
+func estimateTokens(jsonBody []byte, body interface{}) int {
+	tokens := len(jsonBody) / charsPerToken
+	switch r := body.(type) {
+	case models.ResponseRequest:
+		tokens += max(r.MaxOutputTokens.Value(), r.MaxTokens.Value())
+	case *models.ResponseRequest:
+		tokens += max(r.MaxOutputTokens.Value(), r.MaxTokens.Value())
+	}
+	return tokens
+}
@@ -0,0 +1,116 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"time"
+)
+
+// RequestOption is a function that configures a single API call, layered over the client defaults
+type RequestOption func(*Request)
+
+// WithHeader sets an extra header on the request, replacing any existing value
+func WithHeader(key, value string) RequestOption {
+	return func(r *Request) {
+		r.Header.Set(key, value)
+	}
+}
+
+// WithQuery adds a query parameter to the request URL
+func WithQuery(key, value string) RequestOption {
+	return func(r *Request) {
+		if r.Query == nil {
+			r.Query = make(url.Values)
+		}
+		r.Query.Add(key, value)
+	}
+}
+
+// WithRequestTimeout sets a timeout for the call. For streams the timeout covers the whole
+// stream, including reading all events.
+func WithRequestTimeout(timeout time.Duration) RequestOption {
+	return func(r *Request) {
+		r.Timeout = timeout
+	}
+}
+
+// WithRequestAPIKey overrides the client API key for the call
+func WithRequestAPIKey(apiKey string) RequestOption {
+	return func(r *Request) {
+		r.APIKey = apiKey
+	}
+}
+
+// WithRequestOrganization overrides the client organization ID for the call
+func WithRequestOrganization(organization string) RequestOption {
+	return func(r *Request) {
+		r.Organization = organization
+	}
+}
+
+// WithRequestProject overrides the client project ID for the call
+func WithRequestProject(project string) RequestOption {
+	return func(r *Request) {
+		r.Project = project
+	}
+}
+
+// WithIdempotencyKey sets the idempotency key sent with every attempt of the call
+func WithIdempotencyKey(key string) RequestOption {
+	return func(r *Request) {
+		r.Header.Set("Idempotency-Key", key)
+	}
+}
+
+// WithMaxRetries overrides the maximum number of retries for the call.
+// If the client has no retry policy configured, the default policy is used.
+func WithMaxRetries(maxRetries int) RequestOption {
+	return func(r *Request) {
+		r.MaxAttempts = max(maxRetries, 0) + 1
+	}
+}
+
+// retryPolicy returns the retry policy that applies to the request
+func (c *Client) retryPolicy(req *Request) RetryPolicy {
+	policy := c.retry
+	if req.MaxAttempts > 0 {
+		if len(policy.RetryableStatusCodes) == 0 {
+			policy = DefaultRetryPolicy()
+		}
+		policy.MaxAttempts = req.MaxAttempts
+	}
+	return policy
+}
+
+// cancelOnClose releases a per-call context once the response body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close closes the body and cancels the context
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// withTimeout applies the per-call timeout of the request to the handler. The timeout
+// stays in effect until the response body is closed.
+func withTimeout(next Handler) Handler {
+	return func(ctx context.Context, req *Request) (*Response, error) {
+		if req.Timeout <= 0 {
+			return next(ctx, req)
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, req.Timeout)
+		resp, err := next(ctx, req)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		resp.HTTP.Body = &cancelOnClose{ReadCloser: resp.HTTP.Body, cancel: cancel}
+		return resp, nil
+	}
+}
@@ -4,10 +4,14 @@ import (
 	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/gosticks/openai-responses-api-go/models"
 )
@@ -30,132 +34,234 @@ func NewResponses(client *Client) *Responses {
 }
 
 // Create creates a new response
-func (r *Responses) Create(ctx context.Context, request models.ResponseRequest) (*models.ResponseResponse, error) {
+func (r *Responses) Create(ctx context.Context, request models.ResponseRequest, options ...RequestOption) (*models.ResponseResponse, error) {
+	ctx, op := r.client.startOperation(ctx, request)
+
 	var response models.ResponseResponse
-	err := r.client.post(ctx, responsesEndpoint, request, &response)
+	resp, err := r.client.post(ctx, responsesEndpoint, request, &response, options...)
 	if err != nil {
+		op.end("", "", nil, "", err)
 		return nil, err
 	}
+	response.RateLimit = resp.RateLimit
+	response.Metadata = resp.Metadata
+	r.client.logUsage(ctx, &response)
+
+	// Derive the choices from the output items for callers of the Chat Completions style view
+	if len(response.Choices) == 0 && len(response.Output) > 0 {
+		response.Choices = response.Output.Choices(response.Status)
+	}
 
-	// Set the OutputText field based on the first choice's content
-	if len(response.Choices) > 0 && response.Choices[0].Message.Content != "" {
+	// Set the OutputText field based on the output text or the first choice's content
+	if len(response.Output) > 0 {
+		response.OutputText = response.Output.OutputText()
+	} else if len(response.Choices) > 0 && response.Choices[0].Message.Content != "" {
 		response.OutputText = response.Choices[0].Message.Content
 	}
 
+	calls := response.FunctionCalls()
+	for _, call := range calls {
+		op.event(EventToolCall, Attr(AttrGenAIToolName, call.Name), Attr(AttrGenAIToolCallID, call.CallID))
+	}
+	reason := models.FinishReason(response.Status, len(calls) > 0)
+	if reason == "" && len(response.Choices) > 0 {
+		// Servers that only return choices report the finish reason there
+		reason = response.Choices[0].FinishReason
+	}
+	op.end(response.ID, response.Model, response.Usage, reason, nil)
+
 	return &response, nil
 }
 
 // CreateStream creates a new streaming response
-func (r *Responses) CreateStream(ctx context.Context, request models.ResponseRequest) (*ResponsesStream, error) {
+func (r *Responses) CreateStream(ctx context.Context, request models.ResponseRequest, options ...RequestOption) (*ResponsesStream, error) {
 	// Ensure streaming is enabled
 	request.Stream = true
 
-	// Create the request
-	reqBody, err := json.Marshal(request)
-	if err != nil {
-		return nil, err
-	}
-
-	// Construct the URL
-	u := r.client.BaseURL + responsesEndpoint
-
-	// Create the HTTP request
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, strings.NewReader(string(reqBody)))
-	if err != nil {
-		return nil, err
-	}
+	ctx, op := r.client.startOperation(ctx, request)
 
-	// Set headers
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("User-Agent", r.client.UserAgent)
-	req.Header.Set("Authorization", "Bearer "+r.client.APIKey)
-	if r.client.Organization != "" {
-		req.Header.Set("OpenAI-Organization", r.client.Organization)
-	}
+	// The watchdog cancels the stream when a phase exceeds its timeout. The connect timeout starts
+	// with the first attempt, so that time spent queuing for the concurrency and rate limiters
+	// is only bounded by ctx.
+	timeouts := r.client.streamTimeouts
+	logCtx := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	watchdog := newWatchdog(cancel)
+	var connecting sync.Once
 
 	// Make the request
-	resp, err := r.client.HTTPClient.Do(req)
+	resp, err := r.client.send(ctx, &Request{
+		Method: http.MethodPost,
+		Path:   responsesEndpoint,
+		Body:   request,
+		Stream: true,
+		connecting: func() {
+			connecting.Do(func() { watchdog.arm(timeouts.Connect, "stream connect") })
+		},
+	}, options...)
 	if err != nil {
-		return nil, err
-	}
-
-	// Check for errors
-	if resp.StatusCode >= 400 {
-		defer resp.Body.Close()
-		var errResp ErrorResponse
-		if err := json.NewDecoder(resp.Body).Decode(&errResp); err != nil {
-			return nil, fmt.Errorf("error decoding error response: %w", err)
-		}
-		if errResp.Error != nil {
-			errResp.Error.StatusCode = resp.StatusCode
-			return nil, errResp.Error
+		watchdog.stop()
+		cancel()
+		if timeoutErr := watchdog.expired(); timeoutErr != nil {
+			err = timeoutErr
 		}
-		return nil, fmt.Errorf("unknown error, status code: %d", resp.StatusCode)
+		op.end("", "", nil, "", err)
+		return nil, err
 	}
+	watchdog.arm(timeouts.FirstEvent, "first stream event")
+	op.event(EventStreamOpen)
 
 	return &ResponsesStream{
-		reader:   bufio.NewReader(resp.Body),
-		response: resp,
+		reader:    bufio.NewReader(resp.HTTP.Body),
+		response:  resp.HTTP,
+		rateLimit: resp.RateLimit,
+		metadata:  resp.Metadata,
+		watchdog:  watchdog,
+		idle:      timeouts.Idle,
+		cancel:    cancel,
+		client:    r.client,
+		ctx:       logCtx,
+		start:     time.Now(),
+		op:        op,
 	}, nil
 }
 
 // CreateState creates a new response state
-func (r *Responses) CreateState(ctx context.Context, request models.ResponseStateRequest) (*models.ResponseStateResponse, error) {
+func (r *Responses) CreateState(ctx context.Context, request models.ResponseStateRequest, options ...RequestOption) (*models.ResponseStateResponse, error) {
 	var response models.ResponseStateResponse
-	err := r.client.post(ctx, responsesStateEndpoint, request, &response)
+	resp, err := r.client.post(ctx, responsesStateEndpoint, request, &response, options...)
 	if err != nil {
 		return nil, err
 	}
+	response.RateLimit = resp.RateLimit
+	response.Metadata = resp.Metadata
 	return &response, nil
 }
 
 // GetState gets a response state
-func (r *Responses) GetState(ctx context.Context, id string) (*models.ResponseStateResponse, error) {
+func (r *Responses) GetState(ctx context.Context, id string, options ...RequestOption) (*models.ResponseStateResponse, error) {
 	var response models.ResponseStateResponse
-	err := r.client.get(ctx, fmt.Sprintf("%s/%s", responsesStateEndpoint, id), &response)
+	resp, err := r.client.get(ctx, fmt.Sprintf("%s/%s", responsesStateEndpoint, id), &response, options...)
 	if err != nil {
 		return nil, err
 	}
+	response.RateLimit = resp.RateLimit
+	response.Metadata = resp.Metadata
 	return &response, nil
 }
 
 // DeleteState deletes a response state
-func (r *Responses) DeleteState(ctx context.Context, id string) error {
-	return r.client.delete(ctx, fmt.Sprintf("%s/%s", responsesStateEndpoint, id), nil)
+func (r *Responses) DeleteState(ctx context.Context, id string, options ...RequestOption) error {
+	_, err := r.client.delete(ctx, fmt.Sprintf("%s/%s", responsesStateEndpoint, id), nil, options...)
+	return err
 }
 
 // ResponsesStream is a stream of responses
 type ResponsesStream struct {
-	reader   *bufio.Reader
-	response *http.Response
-	err      error
+	reader    *bufio.Reader
+	response  *http.Response
+	rateLimit *models.RateLimitInfo
+	metadata  *models.ResponseMetadata
+	watchdog  *watchdog
+	idle      time.Duration
+	cancel    context.CancelFunc
+	err       error
+
+	// Logging and tracing state
+	client     *Client
+	ctx        context.Context
+	start      time.Time
+	op         *operation
+	events     int
+	responseID string
+	model      string
+	status     string
+	toolCalls  bool
+	firstToken bool
+	usage      *models.Usage
+	finished   bool
 }
 
 // Recv receives the next response from the stream
 func (s *ResponsesStream) Recv() (*models.ResponseStreamResponse, error) {
+	response, err := s.recv()
+	if response != nil {
+		s.observe(response)
+	}
+	if err != nil {
+		s.finish(err)
+	}
+	return response, err
+}
+
+// observe tracks the response ID, model, usage and first token of the stream
+func (s *ResponsesStream) observe(response *models.ResponseStreamResponse) {
+	if response.ID != "" {
+		s.responseID = response.ID
+	}
+	if response.Model != "" {
+		s.model = response.Model
+	}
+	if response.Usage != nil {
+		s.usage = response.Usage
+	}
+	if !s.firstToken && s.op != nil {
+		for _, choice := range response.Choices {
+			if choice.Delta.Content != "" {
+				s.firstToken = true
+				s.op.firstToken()
+				break
+			}
+		}
+	}
+}
+
+// recv reads events until one carries data useful to the caller
+func (s *ResponsesStream) recv() (*models.ResponseStreamResponse, error) {
 	// Check if there was a previous error
 	if s.err != nil {
 		return nil, s.err
 	}
 
-	// Read the next line
+	// Read the next line. The stream only ends cleanly after a completion event or [DONE],
+	// so any read error at this point means the stream was interrupted.
 	line, err := s.reader.ReadString('\n')
 	if err != nil {
+		if s.watchdog != nil {
+			if timeoutErr := s.watchdog.expired(); timeoutErr != nil {
+				s.err = timeoutErr
+				return nil, timeoutErr
+			}
+		}
+		if errors.Is(err, ErrClientClosed) {
+			s.err = err
+			return nil, err
+		}
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		if err = wrapTransportError(err); !errors.Is(err, ErrTimeout) {
+			err = &StreamInterruptedError{Err: err}
+		}
 		s.err = err
 		return nil, err
 	}
 
+	// Data is flowing, so restart the idle timeout
+	if s.watchdog != nil {
+		s.watchdog.arm(s.idle, "stream idle")
+	}
+
 	// Skip empty lines
 	line = strings.TrimSpace(line)
 	if line == "" {
-		return s.Recv()
+		return s.recv()
 	}
 
 	// Check for data prefix
 	const prefix = "data: "
 	if !strings.HasPrefix(line, prefix) {
-		return s.Recv()
+		return s.recv()
 	}
 
 	// Extract the data
@@ -163,6 +269,7 @@ func (s *ResponsesStream) Recv() (*models.ResponseStreamResponse, error) {
 
 	// Check for the end of the stream
 	if data == "[DONE]" {
+		s.stopWatchdog()
 		s.err = io.EOF
 		return nil, io.EOF
 	}
@@ -173,6 +280,7 @@ func (s *ResponsesStream) Recv() (*models.ResponseStreamResponse, error) {
 		s.err = err
 		return nil, err
 	}
+	s.events++
 
 	// Create a response object
 	response := &models.ResponseStreamResponse{}
@@ -240,6 +348,11 @@ func (s *ResponsesStream) Recv() (*models.ResponseStreamResponse, error) {
 	case "response.output_item.done":
 		// An output item has completed
 		if item, ok := eventData["item"].(map[string]interface{}); ok {
+			// Keep the typed item, e.g. reasoning items to carry forward
+			if itemData, err := json.Marshal(item); err == nil {
+				response.Item, _ = models.UnmarshalOutputItem(itemData)
+			}
+
 			if index, ok := eventData["output_index"].(float64); ok {
 				itemType, _ := item["type"].(string)
 
@@ -256,6 +369,10 @@ func (s *ResponsesStream) Recv() (*models.ResponseStreamResponse, error) {
 					}
 					toolCall.Function.Name = name
 					toolCall.Function.Arguments = arguments
+					s.toolCalls = true
+					if s.op != nil {
+						s.op.event(EventToolCall, Attr(AttrGenAIToolName, name), Attr(AttrGenAIToolCallID, callID))
+					}
 
 					response.Choices = []models.ResponseStreamChoice{
 						{
@@ -269,6 +386,20 @@ func (s *ResponsesStream) Recv() (*models.ResponseStreamResponse, error) {
 			}
 		}
 
+	// Reasoning summary events
+	case "response.reasoning_summary_text.delta":
+		delta, _ := eventData["delta"].(string)
+		if index, ok := eventData["output_index"].(float64); ok {
+			response.Choices = []models.ResponseStreamChoice{
+				{
+					Index: int(index),
+					Delta: models.ResponseStreamDelta{
+						ReasoningSummary: delta,
+					},
+				},
+			}
+		}
+
 	// File search related events
 	case "response.file_search_call.in_progress",
 		"response.file_search_call.searching",
@@ -373,7 +504,20 @@ func (s *ResponsesStream) Recv() (*models.ResponseStreamResponse, error) {
 			}
 		}
 
+	case "error":
+		// The server reported an error in the middle of the stream
+		s.err = streamError(eventData)
+		return nil, s.err
+
+	case "response.failed":
+		// The response failed, surface the error of the response
+		respData, _ := eventData["response"].(map[string]interface{})
+		errData, _ := respData["error"].(map[string]interface{})
+		s.err = streamError(errData)
+		return nil, s.err
+
 	case "response.completed", "response.incomplete":
+		s.status = strings.TrimPrefix(eventType, "response.")
 		// Extract usage data if available
 		if respData, ok := eventData["response"].(map[string]interface{}); ok {
 			response.ID, _ = respData["id"].(string)
@@ -384,38 +528,115 @@ func (s *ResponsesStream) Recv() (*models.ResponseStreamResponse, error) {
 			response.Model, _ = respData["model"].(string)
 
 			if usageData, ok := respData["usage"].(map[string]interface{}); ok {
-				promptTokens, _ := usageData["prompt_tokens"].(float64)
-				completionTokens, _ := usageData["completion_tokens"].(float64)
-				totalTokens, _ := usageData["total_tokens"].(float64)
-
-				response.Usage = &models.Usage{
-					PromptTokens:     int(promptTokens),
-					CompletionTokens: int(completionTokens),
-					TotalTokens:      int(totalTokens),
+				// Decode the usage like a non-streaming response, including the token details
+				if data, err := json.Marshal(usageData); err == nil {
+					var usage models.Usage
+					if json.Unmarshal(data, &usage) == nil {
+						response.Usage = &usage
+					}
 				}
 			}
 		}
 
 		// Signal that this is the end of the stream
+		s.stopWatchdog()
 		s.err = io.EOF
 	}
 
 	// Skip events that don't contain useful data for our client
-	if len(response.Choices) == 0 && response.ID == "" && response.Usage == nil {
-		return s.Recv()
+	if len(response.Choices) == 0 && response.ID == "" && response.Usage == nil && response.Item == nil {
+		return s.recv()
 	}
 
 	return response, nil
 }
 
+// streamError converts an error object received in a stream event into a typed error
+func streamError(data map[string]interface{}) error {
+	apiErr := &APIError{Message: "stream failed"}
+	if message, ok := data["message"].(string); ok {
+		apiErr.Message = message
+	}
+	if code, ok := data["code"].(string); ok {
+		apiErr.Code = &code
+	}
+	if param, ok := data["param"].(string); ok {
+		apiErr.Param = &param
+	}
+	apiErr.Type, _ = data["type"].(string)
+	if apiErr.Code != nil && *apiErr.Code == "rate_limit_exceeded" {
+		apiErr.StatusCode = http.StatusTooManyRequests
+	} else if apiErr.Code != nil && *apiErr.Code == "server_error" {
+		apiErr.StatusCode = http.StatusInternalServerError
+	}
+	return classifyError(apiErr, nil)
+}
+
+// stopWatchdog disarms the stream timeouts once the stream has ended
+func (s *ResponsesStream) stopWatchdog() {
+	if s.watchdog != nil {
+		s.watchdog.stop()
+	}
+}
+
+// finish ends the span and logs the outcome of the stream once it has ended
+func (s *ResponsesStream) finish(err error) {
+	if s.finished {
+		return
+	}
+	s.finished = true
+
+	if s.op != nil {
+		var opErr error
+		if err != io.EOF {
+			opErr = err
+		}
+		s.op.end(s.responseID, s.model, s.usage, models.FinishReason(s.status, s.toolCalls), opErr)
+	}
+	if s.client == nil || s.client.logger == nil {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.Int("events", s.events),
+		slog.Duration("duration", time.Since(s.start)),
+	}
+	if s.metadata != nil && s.metadata.RequestID != "" {
+		attrs = append(attrs, slog.String("request_id", s.metadata.RequestID))
+	}
+	attrs = append(attrs, usageAttrs(s.usage)...)
+
+	if err != nil && err != io.EOF {
+		attrs = append(attrs, slog.String("error", redactString(err.Error())))
+		s.client.logger.LogAttrs(s.ctx, s.client.logConfig.ErrorLevel, "openai stream failed", attrs...)
+		return
+	}
+	s.client.logger.LogAttrs(s.ctx, s.client.logConfig.Level, "openai stream finished", attrs...)
+}
+
 // Close closes the stream
 func (s *ResponsesStream) Close() error {
+	s.finish(s.err)
+	s.stopWatchdog()
+	if s.cancel != nil {
+		defer s.cancel()
+	}
 	if s.response != nil && s.response.Body != nil {
 		return s.response.Body.Close()
 	}
 	return nil
 }
 
+// RateLimit returns the rate limit state reported by the API when the stream was opened
+func (s *ResponsesStream) RateLimit() *models.RateLimitInfo {
+	return s.rateLimit
+}
+
+// Metadata returns the HTTP response details of the stream, such as the request ID
+func (s *ResponsesStream) Metadata() *models.ResponseMetadata {
+	return s.metadata
+}
+
 // Err returns the last error that occurred while reading from the stream
 func (s *ResponsesStream) Err() error {
 	if s.err == io.EOF {
@@ -432,6 +653,8 @@ type ResponsesStreamAccumulator struct {
 	Model   string
 	Choices []models.ResponseChoice
 	Usage   *models.Usage
+	// Output holds the completed output items, e.g. reasoning items to carry forward
+	Output models.OutputItems
 }
 
 // AddChunk adds a chunk to the accumulator
@@ -470,6 +693,10 @@ func (a *ResponsesStreamAccumulator) AddChunk(chunk *models.ResponseStreamRespon
 		a.Usage = chunk.Usage
 	}
 
+	if chunk.Item != nil {
+		a.Output = append(a.Output, chunk.Item)
+	}
+
 	// Ensure we have at least one choice for content
 	if len(a.Choices) == 0 && len(chunk.Choices) > 0 {
 		a.Choices = []models.ResponseChoice{
@@ -576,5 +803,6 @@ func (a *ResponsesStreamAccumulator) ToResponse() *models.ResponseResponse {
 		Model:   a.Model,
 		Choices: choices,
 		Usage:   a.Usage,
+		Output:  a.Output,
 	}
 }
@@ -0,0 +1,184 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// maxRetryAfter is the longest server-provided retry delay that is honored
+	maxRetryAfter = 60 * time.Second
+	// maxDrainBytes is the maximum number of bytes read from a discarded response body
+	maxDrainBytes = 4 << 10
+)
+
+// RetryPolicy configures how failed requests are retried
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one.
+	// Values below 2 disable retries.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry
+	InitialBackoff time.Duration
+	// MaxBackoff is the upper bound for the delay between attempts
+	MaxBackoff time.Duration
+	// Multiplier is the factor by which the backoff grows after each attempt
+	Multiplier float64
+	// Jitter is the fraction of the backoff that is randomized, between 0 and 1
+	Jitter float64
+	// RetryableStatusCodes are the HTTP status codes that trigger a retry
+	RetryableStatusCodes []int
+}
+
+// DefaultRetryPolicy returns a retry policy with sensible defaults for the OpenAI API
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     8 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.25,
+		RetryableStatusCodes: []int{
+			http.StatusRequestTimeout,
+			http.StatusConflict,
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// WithRetryPolicy sets the retry policy for the client
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+// shouldRetry reports whether an attempt that produced resp and err should be retried
+func (p RetryPolicy) shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		// Context cancellation and deadlines are final
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+
+	// Successful responses are final, whatever the headers say
+	if resp.StatusCode < 400 {
+		return false
+	}
+
+	// The server may explicitly tell us whether to retry a failed request
+	switch resp.Header.Get("x-should-retry") {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+
+	for _, code := range p.RetryableStatusCodes {
+		if resp.StatusCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+// delay returns how long to wait before the next attempt after the given attempt number
+func (p RetryPolicy) delay(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if d, ok := retryAfter(resp.Header); ok {
+			return d
+		}
+	}
+
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	backoff := float64(p.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 && backoff > float64(p.MaxBackoff) {
+		backoff = float64(p.MaxBackoff)
+	}
+
+	jitter := min(max(p.Jitter, 0), 1)
+	backoff *= 1 - jitter*rand.Float64()
+
+	return time.Duration(backoff)
+}
+
+// retryAfter parses the retry-after-ms and Retry-After headers
+func retryAfter(header http.Header) (time.Duration, bool) {
+	var d time.Duration
+	if v := header.Get("retry-after-ms"); v != "" {
+		if ms, err := strconv.ParseFloat(v, 64); err == nil {
+			d = time.Duration(ms * float64(time.Millisecond))
+		}
+	} else if v := header.Get("Retry-After"); v != "" {
+		if secs, err := strconv.ParseFloat(v, 64); err == nil {
+			d = time.Duration(secs * float64(time.Second))
+		} else if t, err := http.ParseTime(v); err == nil {
+			d = time.Until(t)
+		}
+	}
+
+	if d <= 0 || d > maxRetryAfter {
+		return 0, false
+	}
+	return d, true
+}
+
+// do sends the request produced by newRequest, retrying according to the given retry policy.
+// newRequest is called once per attempt so that the request body can be replayed.
+// Every attempt is throttled by the client's rate limiter, if any, using the estimated token count,
+// and the response of every attempt is passed to observe.
+func (c *Client) do(ctx context.Context, policy RetryPolicy, tokens int, newRequest func() (*http.Request, error), observe func(*http.Response)) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		if c.rateLimiter != nil {
+			if err := c.rateLimiter.Wait(ctx, tokens); err != nil {
+				return nil, err
+			}
+		}
+
+		req, err := newRequest()
+		if err != nil {
+			return nil, err
+		}
+
+		start := time.Now()
+		resp, err := c.httpClient.Do(req)
+		if c.har != nil {
+			c.har.record(req, start, resp, err)
+		}
+		if resp != nil {
+			if c.rateLimiter != nil {
+				c.rateLimiter.Observe(parseRateLimitInfo(resp.Header))
+			}
+			observe(resp)
+		}
+		if attempt >= policy.MaxAttempts || ctx.Err() != nil || !policy.shouldRetry(resp, err) {
+			return resp, err
+		}
+
+		delay := policy.delay(attempt, resp)
+		if resp != nil {
+			// Drain the body so the connection can be reused
+			io.CopyN(io.Discard, resp.Body, maxDrainBytes)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gosticks/openai-responses-api-go/models"
+)
+
+func TestRetryIgnoresShouldRetryOnSuccess(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("x-should-retry", "true")
+		w.Write([]byte(`{"id":"resp_1","status":"completed"}`))
+	}))
+	defer server.Close()
+
+	policy := DefaultRetryPolicy()
+	policy.InitialBackoff = time.Millisecond
+	c := NewClient(WithAPIKey("key"), WithBaseURL(server.URL), WithRetryPolicy(policy))
+	if _, err := NewResponses(c).Create(context.Background(), models.ResponseRequest{Model: "m"}); err != nil {
+		t.Fatal(err)
+	}
+	if n := requests.Load(); n != 1 {
+		t.Fatalf("got %d requests, want 1", n)
+	}
+}
+
+func TestRetryHonorsShouldRetryOnFailure(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.Header().Set("x-should-retry", "true")
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error":{"message":"conflict"}}`))
+			return
+		}
+		w.Write([]byte(`{"id":"resp_1","status":"completed"}`))
+	}))
+	defer server.Close()
+
+	policy := DefaultRetryPolicy()
+	policy.InitialBackoff = time.Millisecond
+	c := NewClient(WithAPIKey("key"), WithBaseURL(server.URL), WithRetryPolicy(policy))
+	if _, err := NewResponses(c).Create(context.Background(), models.ResponseRequest{Model: "m"}); err != nil {
+		t.Fatal(err)
+	}
+	if n := requests.Load(); n != 2 {
+		t.Fatalf("got %d requests, want 2", n)
+	}
+}
@@ -0,0 +1,140 @@
+package client
+
+import (
+	"context"
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// Shutdown stops the client from accepting new calls and waits until the calls and streams in flight
+// have finished, that is until their responses are decoded or their streams are closed. If ctx ends first,
+// the remaining calls and streams are canceled, fail with a ClientClosedError, and ctx.Err() is returned.
+// Calls made after Shutdown fail with a ClientClosedError. Clients derived with With share the shutdown
+// state of the client they were derived from.
+func (c *Client) Shutdown(ctx context.Context) error {
+	return c.lifecycle.shutdown(ctx)
+}
+
+// activeCall is a call tracked for shutdown
+type activeCall struct {
+	cancel  context.CancelFunc
+	aborted atomic.Bool
+}
+
+// lifecycle tracks the calls in flight so that the client can shut down gracefully
+type lifecycle struct {
+	mu     sync.Mutex
+	closed bool
+	calls  map[*activeCall]struct{}
+	idle   chan struct{}
+}
+
+// newLifecycle creates the lifecycle of a new client
+func newLifecycle() *lifecycle {
+	return &lifecycle{calls: make(map[*activeCall]struct{})}
+}
+
+// begin registers a call and returns a context that is canceled if the call is aborted by shutdown
+func (l *lifecycle) begin(ctx context.Context) (context.Context, *activeCall, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.closed {
+		return nil, nil, &ClientClosedError{}
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	call := &activeCall{cancel: cancel}
+	l.calls[call] = struct{}{}
+	return ctx, call, nil
+}
+
+// end unregisters a finished call
+func (l *lifecycle) end(call *activeCall) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	call.cancel()
+	delete(l.calls, call)
+	if l.closed && len(l.calls) == 0 && l.idle != nil {
+		close(l.idle)
+		l.idle = nil
+	}
+}
+
+// shutdown closes the lifecycle and waits for the calls in flight, aborting them when ctx ends
+func (l *lifecycle) shutdown(ctx context.Context) error {
+	l.mu.Lock()
+	l.closed = true
+	if len(l.calls) == 0 {
+		l.mu.Unlock()
+		return nil
+	}
+	if l.idle == nil {
+		l.idle = make(chan struct{})
+	}
+	idle := l.idle
+	l.mu.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		for call := range l.calls {
+			call.aborted.Store(true)
+			call.cancel()
+		}
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// track is the pipeline stage that registers the call for graceful shutdown. The call stays in flight
+// until the response body is closed, so streams count as in flight until closed.
+func (c *Client) track(next Handler) Handler {
+	return func(ctx context.Context, req *Request) (*Response, error) {
+		ctx, call, err := c.lifecycle.begin(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := next(ctx, req)
+		if err != nil {
+			c.lifecycle.end(call)
+			if call.aborted.Load() {
+				return nil, &ClientClosedError{Canceled: true}
+			}
+			return nil, err
+		}
+		resp.HTTP.Body = &trackedBody{ReadCloser: resp.HTTP.Body, lifecycle: c.lifecycle, call: call}
+		return resp, nil
+	}
+}
+
+// trackedBody ends the call once the response body is closed, and reports reads
+// failing because of a shutdown as a ClientClosedError
+type trackedBody struct {
+	io.ReadCloser
+	lifecycle *lifecycle
+	call      *activeCall
+	once      sync.Once
+}
+
+// Read reads from the body
+func (b *trackedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err != nil && err != io.EOF && b.call.aborted.Load() {
+		err = &ClientClosedError{Canceled: true}
+	}
+	return n, err
+}
+
+// Close closes the body and ends the call
+func (b *trackedBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(func() {
+		b.lifecycle.end(b.call)
+	})
+	return err
+}
@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gosticks/openai-responses-api-go/models"
+)
+
+func TestShutdownCancelsOpenStream(t *testing.T) {
+	server := newHangingStreamServer(t)
+	c := NewClient(WithAPIKey("key"), WithBaseURL(server.URL))
+	responses := NewResponses(c)
+
+	stream, err := responses.CreateStream(context.Background(), models.ResponseRequest{Model: "m"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stream.Close()
+	if _, err := stream.Recv(); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := c.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got shutdown error %v, want context.DeadlineExceeded", err)
+	}
+
+	_, err = stream.Recv()
+	var closedErr *ClientClosedError
+	if !errors.As(err, &closedErr) || !closedErr.Canceled {
+		t.Fatalf("got stream error %v, want a canceled ClientClosedError", err)
+	}
+
+	if _, err := responses.Create(context.Background(), models.ResponseRequest{Model: "m"}); !errors.Is(err, ErrClientClosed) {
+		t.Fatalf("got error %v for a call after shutdown, want ErrClientClosed", err)
+	}
+}
+
+func TestShutdownWaitsForOpenStream(t *testing.T) {
+	server := newHangingStreamServer(t)
+	c := NewClient(WithAPIKey("key"), WithBaseURL(server.URL))
+
+	stream, err := NewResponses(c).CreateStream(context.Background(), models.ResponseRequest{Model: "m"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan error)
+	go func() {
+		done <- c.Shutdown(context.Background())
+	}()
+	select {
+	case err := <-done:
+		t.Fatalf("shutdown returned %v with a stream open", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	stream.Close()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("shutdown did not return after the stream was closed")
+	}
+}
@@ -0,0 +1,250 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/gosticks/openai-responses-api-go/models"
+)
+
+// Attribute names following the OpenTelemetry semantic conventions for generative AI
+const (
+	AttrGenAISystem               = "gen_ai.system"
+	AttrGenAIOperationName        = "gen_ai.operation.name"
+	AttrGenAIRequestModel         = "gen_ai.request.model"
+	AttrGenAIRequestTemperature   = "gen_ai.request.temperature"
+	AttrGenAIRequestTopP          = "gen_ai.request.top_p"
+	AttrGenAIRequestMaxTokens     = "gen_ai.request.max_tokens"
+	AttrGenAIResponseID           = "gen_ai.response.id"
+	AttrGenAIResponseModel        = "gen_ai.response.model"
+	AttrGenAIResponseFinishReason = "gen_ai.response.finish_reasons"
+	AttrGenAIUsageInputTokens     = "gen_ai.usage.input_tokens"
+	AttrGenAIUsageOutputTokens    = "gen_ai.usage.output_tokens"
+	AttrGenAITokenType            = "gen_ai.token.type"
+	AttrGenAIToolName             = "gen_ai.tool.name"
+	AttrGenAIToolCallID           = "gen_ai.tool.call.id"
+	AttrServerAddress             = "server.address"
+	AttrErrorType                 = "error.type"
+	AttrRequestPriority           = "openai.request.priority"
+	AttrHedgeAttempts             = "openai.hedge.attempts"
+)
+
+// Span event names recorded by the client
+const (
+	EventStreamOpen = "gen_ai.stream.open"
+	EventFirstToken = "gen_ai.first_token"
+	EventToolCall   = "gen_ai.tool.call"
+)
+
+// Metric names recorded by the client
+const (
+	// MetricOperationDuration is the duration of a call in seconds
+	MetricOperationDuration = "gen_ai.client.operation.duration"
+	// MetricTokenUsage is the number of input or output tokens used by a call
+	MetricTokenUsage = "gen_ai.client.token.usage"
+	// MetricTimeToFirstToken is the time until the first output token of a stream in seconds
+	MetricTimeToFirstToken = "gen_ai.client.time_to_first_token"
+	// MetricQueueWait is the time a call waited for a slot of the concurrency limiter in seconds
+	MetricQueueWait = "openai.client.queue.wait"
+	// MetricHedgeWinner is the 1-based attempt that won a hedged call
+	MetricHedgeWinner = "openai.client.hedge.winner"
+)
+
+// operationName is the GenAI operation name for the Responses API
+const operationName = "chat"
+
+// Attribute is a key-value pair attached to spans and metrics
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// Attr creates an attribute
+func Attr(key string, value interface{}) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Tracer starts spans. Implementations adapt the client to a tracing library such as OpenTelemetry.
+type Tracer interface {
+	// Start starts a span and returns a context carrying it
+	Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span)
+}
+
+// Span is a traced unit of work
+type Span interface {
+	// SetAttributes sets attributes on the span
+	SetAttributes(attrs ...Attribute)
+	// AddEvent records an event on the span
+	AddEvent(name string, attrs ...Attribute)
+	// RecordError records an error and marks the span as failed
+	RecordError(err error)
+	// End ends the span
+	End()
+}
+
+// Meter records metrics. Implementations adapt the client to a metrics library such as OpenTelemetry.
+type Meter interface {
+	// RecordHistogram records a value of the named histogram
+	RecordHistogram(ctx context.Context, name string, value float64, attrs ...Attribute)
+}
+
+// WithTracer sets the tracer for the client
+func WithTracer(tracer Tracer) ClientOption {
+	return func(c *Client) {
+		c.tracer = tracer
+	}
+}
+
+// WithMeter sets the meter for the client
+func WithMeter(meter Meter) ClientOption {
+	return func(c *Client) {
+		c.meter = meter
+	}
+}
+
+// noopSpan is used when no tracer is configured
+type noopSpan struct{}
+
+func (noopSpan) SetAttributes(...Attribute)    {}
+func (noopSpan) AddEvent(string, ...Attribute) {}
+func (noopSpan) RecordError(error)             {}
+func (noopSpan) End()                          {}
+
+// operation traces and measures a single Create or CreateStream call
+type operation struct {
+	client *Client
+	ctx    context.Context
+	span   Span
+	start  time.Time
+	attrs  []Attribute
+
+	mu     sync.Mutex
+	server string
+}
+
+// operationKey is the context key for the operation of a call
+type operationKey struct{}
+
+// startOperation starts the span for a call with the request attributes
+func (c *Client) startOperation(ctx context.Context, request models.ResponseRequest) (context.Context, *operation) {
+	attrs := []Attribute{
+		Attr(AttrGenAISystem, "openai"),
+		Attr(AttrGenAIOperationName, operationName),
+		Attr(AttrGenAIRequestModel, request.Model),
+	}
+
+	spanAttrs := attrs
+	if temperature, ok := request.Temperature.Get(); ok {
+		spanAttrs = append(spanAttrs, Attr(AttrGenAIRequestTemperature, temperature))
+	}
+	if topP, ok := request.TopP.Get(); ok {
+		spanAttrs = append(spanAttrs, Attr(AttrGenAIRequestTopP, topP))
+	}
+	if maxTokens, ok := request.MaxOutputTokens.Get(); ok {
+		spanAttrs = append(spanAttrs, Attr(AttrGenAIRequestMaxTokens, maxTokens))
+	}
+
+	op := &operation{client: c, start: time.Now(), attrs: attrs, span: noopSpan{}}
+	if c.tracer != nil {
+		ctx, op.span = c.tracer.Start(ctx, fmt.Sprintf("%s %s", operationName, request.Model), spanAttrs...)
+	}
+	op.ctx = ctx
+	return context.WithValue(ctx, operationKey{}, op), op
+}
+
+// attempting records the endpoint an attempt of the call is sent to in the server.address attribute
+func (c *Client) attempting(ctx context.Context, u *url.URL) {
+	op, ok := ctx.Value(operationKey{}).(*operation)
+	if !ok || u.Hostname() == "" {
+		return
+	}
+	op.mu.Lock()
+	op.server = u.Hostname()
+	op.mu.Unlock()
+	op.span.SetAttributes(Attr(AttrServerAddress, u.Hostname()))
+}
+
+// metricAttrs returns the attributes of the metrics of the call, including the server of the latest attempt
+func (o *operation) metricAttrs() []Attribute {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	attrs := slices.Clip(o.attrs)
+	if o.server != "" {
+		attrs = append(attrs, Attr(AttrServerAddress, o.server))
+	}
+	return slices.Clip(attrs)
+}
+
+// event records an event on the span
+func (o *operation) event(name string, attrs ...Attribute) {
+	o.span.AddEvent(name, attrs...)
+}
+
+// firstToken records the arrival of the first output token of a stream
+func (o *operation) firstToken() {
+	elapsed := time.Since(o.start)
+	o.span.AddEvent(EventFirstToken)
+	if o.client.meter != nil {
+		o.client.meter.RecordHistogram(o.ctx, MetricTimeToFirstToken, elapsed.Seconds(), o.metricAttrs()...)
+	}
+}
+
+// end finishes the span and records the metrics of the call
+func (o *operation) end(id, model string, usage *models.Usage, finishReason string, err error) {
+	attrs := o.metricAttrs()
+	if model != "" {
+		attrs = append(attrs, Attr(AttrGenAIResponseModel, model))
+	}
+	if err != nil && !errors.Is(err, context.Canceled) {
+		attrs = append(attrs, Attr(AttrErrorType, errorType(err)))
+		o.span.RecordError(err)
+	}
+	attrs = slices.Clip(attrs)
+
+	spanAttrs := attrs
+	if id != "" {
+		spanAttrs = append(spanAttrs, Attr(AttrGenAIResponseID, id))
+	}
+	if finishReason != "" {
+		spanAttrs = append(spanAttrs, Attr(AttrGenAIResponseFinishReason, []string{finishReason}))
+	}
+	if usage != nil {
+		spanAttrs = append(spanAttrs,
+			Attr(AttrGenAIUsageInputTokens, usage.InputTokens),
+			Attr(AttrGenAIUsageOutputTokens, usage.OutputTokens),
+		)
+	}
+	o.span.SetAttributes(spanAttrs...)
+	o.span.End()
+
+	if meter := o.client.meter; meter != nil {
+		meter.RecordHistogram(o.ctx, MetricOperationDuration, time.Since(o.start).Seconds(), attrs...)
+		if usage != nil {
+			meter.RecordHistogram(o.ctx, MetricTokenUsage, float64(usage.InputTokens), append(attrs, Attr(AttrGenAITokenType, "input"))...)
+			meter.RecordHistogram(o.ctx, MetricTokenUsage, float64(usage.OutputTokens), append(attrs, Attr(AttrGenAITokenType, "output"))...)
+		}
+	}
+}
+
+// errorType returns a low-cardinality description of an error for the error.type attribute
+func errorType(err error) string {
+	var apiErr *APIError
+	switch {
+	case errors.Is(err, ErrTimeout):
+		return "timeout"
+	case errors.Is(err, ErrStreamInterrupted):
+		return "stream_interrupted"
+	case errors.As(err, &apiErr):
+		if apiErr.Type != "" {
+			return apiErr.Type
+		}
+		return fmt.Sprintf("%d", apiErr.StatusCode)
+	}
+	return fmt.Sprintf("%T", err)
+}
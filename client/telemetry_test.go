@@ -0,0 +1,208 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gosticks/openai-responses-api-go/models"
+)
+
+// recordingSpan records the attributes and events of a span
+type recordingSpan struct {
+	mu     sync.Mutex
+	attrs  map[string]interface{}
+	events []string
+}
+
+func (s *recordingSpan) SetAttributes(attrs ...Attribute) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, attr := range attrs {
+		s.attrs[attr.Key] = attr.Value
+	}
+}
+
+func (s *recordingSpan) AddEvent(name string, attrs ...Attribute) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, name)
+}
+
+func (s *recordingSpan) RecordError(error) {}
+func (s *recordingSpan) End()              {}
+
+// recordingTracer records the spans it starts
+type recordingTracer struct {
+	mu    sync.Mutex
+	spans []*recordingSpan
+}
+
+func (t *recordingTracer) Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	span := &recordingSpan{attrs: map[string]interface{}{}}
+	span.SetAttributes(attrs...)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.spans = append(t.spans, span)
+	return ctx, span
+}
+
+// recordingMeter records the token usage it is given by token type, and the attributes of the
+// operation duration
+type recordingMeter struct {
+	mu       sync.Mutex
+	tokens   map[string]float64
+	duration map[string]interface{}
+}
+
+func (m *recordingMeter) RecordHistogram(ctx context.Context, name string, value float64, attrs ...Attribute) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	switch name {
+	case MetricTokenUsage:
+		for _, attr := range attrs {
+			if attr.Key == AttrGenAITokenType {
+				m.tokens[attr.Value.(string)] = value
+			}
+		}
+	case MetricOperationDuration:
+		m.duration = map[string]interface{}{}
+		for _, attr := range attrs {
+			m.duration[attr.Key] = attr.Value
+		}
+	}
+}
+
+func TestTelemetryUsage(t *testing.T) {
+	const usage = `{"input_tokens":11,"output_tokens":7,"total_tokens":18,"output_tokens_details":{"reasoning_tokens":3}}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if strings.Contains(string(body), `"stream":true`) {
+			w.Header().Set("Content-Type", "text/event-stream")
+			io.WriteString(w, `data: {"type":"response.completed","response":{"id":"resp_1","status":"completed","usage":`+usage+"}}\n\n")
+			return
+		}
+		io.WriteString(w, `{"id":"resp_1","status":"completed","usage":`+usage+`}`)
+	}))
+	defer server.Close()
+
+	for _, stream := range []bool{false, true} {
+		tracer := &recordingTracer{}
+		meter := &recordingMeter{tokens: map[string]float64{}}
+		responses := NewResponses(NewClient(WithAPIKey("key"), WithBaseURL(server.URL), WithTracer(tracer), WithMeter(meter)))
+
+		var got *models.Usage
+		if stream {
+			s, err := responses.CreateStream(context.Background(), models.ResponseRequest{Model: "m"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for {
+				chunk, err := s.Recv()
+				if err != nil {
+					break
+				}
+				if chunk.Usage != nil {
+					got = chunk.Usage
+				}
+			}
+			s.Close()
+		} else {
+			resp, err := responses.Create(context.Background(), models.ResponseRequest{Model: "m"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = resp.Usage
+		}
+
+		if got == nil || got.InputTokens != 11 || got.OutputTokens != 7 || got.ReasoningTokens() != 3 {
+			t.Fatalf("stream=%v: got usage %+v", stream, got)
+		}
+		span := tracer.spans[0]
+		if span.attrs[AttrGenAIUsageInputTokens] != 11 || span.attrs[AttrGenAIUsageOutputTokens] != 7 {
+			t.Errorf("stream=%v: got span attributes %v", stream, span.attrs)
+		}
+		if meter.tokens["input"] != 11 || meter.tokens["output"] != 7 {
+			t.Errorf("stream=%v: got token usage %v", stream, meter.tokens)
+		}
+	}
+}
+
+func TestTelemetryToolCalls(t *testing.T) {
+	const call = `{"type":"function_call","id":"fc_1","call_id":"call_1","name":"get_weather","arguments":"{}"}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if strings.Contains(string(body), `"stream":true`) {
+			w.Header().Set("Content-Type", "text/event-stream")
+			io.WriteString(w, `data: {"type":"response.output_item.done","output_index":0,"item":`+call+"}\n\n")
+			io.WriteString(w, `data: {"type":"response.completed","response":{"id":"resp_1","status":"completed"}}`+"\n\n")
+			return
+		}
+		io.WriteString(w, `{"id":"resp_1","status":"completed","output":[`+call+`]}`)
+	}))
+	defer server.Close()
+
+	for _, stream := range []bool{false, true} {
+		tracer := &recordingTracer{}
+		responses := NewResponses(NewClient(WithAPIKey("key"), WithBaseURL(server.URL), WithTracer(tracer)))
+		if stream {
+			s, err := responses.CreateStream(context.Background(), models.ResponseRequest{Model: "m"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for {
+				if _, err := s.Recv(); err != nil {
+					break
+				}
+			}
+			s.Close()
+		} else if _, err := responses.Create(context.Background(), models.ResponseRequest{Model: "m"}); err != nil {
+			t.Fatal(err)
+		}
+
+		span := tracer.spans[0]
+		if !slices.Contains(span.events, EventToolCall) {
+			t.Errorf("stream=%v: got events %v, want %s", stream, span.events, EventToolCall)
+		}
+		if reasons, _ := span.attrs[AttrGenAIResponseFinishReason].([]string); !slices.Equal(reasons, []string{"tool_calls"}) {
+			t.Errorf("stream=%v: got finish reasons %v, want [tool_calls]", stream, span.attrs[AttrGenAIResponseFinishReason])
+		}
+	}
+}
+
+func TestTelemetryServerAddress(t *testing.T) {
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer down.Close()
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"id":"resp_1","status":"completed"}`)
+	}))
+	defer up.Close()
+
+	// Address the serving endpoint by another host name than the failing one
+	upURL := strings.Replace(up.URL, "127.0.0.1", "localhost", 1)
+	tracer := &recordingTracer{}
+	meter := &recordingMeter{tokens: map[string]float64{}}
+	c := NewClient(
+		WithAPIKey("key"),
+		WithEndpoints(Endpoint{BaseURL: down.URL}, Endpoint{BaseURL: upURL}),
+		WithTracer(tracer),
+		WithMeter(meter),
+	)
+	if _, err := NewResponses(c).Create(context.Background(), models.ResponseRequest{Model: "m"}, WithMaxRetries(0)); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := tracer.spans[0].attrs[AttrServerAddress]; got != "localhost" {
+		t.Errorf("got span server.address %v, want localhost", got)
+	}
+	if got := meter.duration[AttrServerAddress]; got != "localhost" {
+		t.Errorf("got metric server.address %v, want localhost", got)
+	}
+}
@@ -0,0 +1,119 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrUnknownTenant is returned when a call selects a tenant that is not registered
+var ErrUnknownTenant = errors.New("openai: unknown tenant")
+
+// Tenant holds the attribution and credentials used for the calls of one tenant.
+// Empty fields fall back to the client configuration.
+type Tenant struct {
+	// Organization is the organization ID sent in the OpenAI-Organization header
+	Organization string
+	// Project is the project ID sent in the OpenAI-Project header
+	Project string
+	// APIKey is the API key used for the calls of the tenant
+	APIKey string
+}
+
+// TenantRegistry maps tenant IDs to their configuration. It is safe for concurrent use,
+// so tenants can be registered and removed while calls are in flight.
+type TenantRegistry struct {
+	mu      sync.RWMutex
+	tenants map[string]Tenant
+}
+
+// NewTenantRegistry creates a registry holding the given tenants
+func NewTenantRegistry(tenants map[string]Tenant) *TenantRegistry {
+	registry := &TenantRegistry{tenants: make(map[string]Tenant, len(tenants))}
+	for id, tenant := range tenants {
+		registry.tenants[id] = tenant
+	}
+	return registry
+}
+
+// Register adds or replaces a tenant
+func (r *TenantRegistry) Register(id string, tenant Tenant) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tenants[id] = tenant
+}
+
+// Remove removes a tenant
+func (r *TenantRegistry) Remove(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.tenants, id)
+}
+
+// Lookup returns the tenant with the given ID
+func (r *TenantRegistry) Lookup(id string) (Tenant, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	tenant, ok := r.tenants[id]
+	return tenant, ok
+}
+
+// WithTenantRegistry sets the registry consulted for calls that select a tenant
+func WithTenantRegistry(registry *TenantRegistry) ClientOption {
+	return func(c *Client) {
+		c.tenants = registry
+	}
+}
+
+// tenantKey is the context key for the tenant ID
+type tenantKey struct{}
+
+// ContextWithTenant returns a context that selects the tenant for calls made with it
+func ContextWithTenant(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, id)
+}
+
+// TenantFromContext returns the tenant ID selected by ctx, or "" if there is none
+func TenantFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(tenantKey{}).(string)
+	return id
+}
+
+// WithTenant selects the tenant for the call, taking precedence over a tenant selected by the context
+func WithTenant(id string) RequestOption {
+	return func(r *Request) {
+		r.Tenant = id
+	}
+}
+
+// applyTenant applies the organization, project and key of the tenant selected for the request.
+// Values set explicitly for the call by request options take precedence over the tenant.
+func (c *Client) applyTenant(ctx context.Context, req *Request) error {
+	if req.Tenant == "" {
+		req.Tenant = TenantFromContext(ctx)
+	}
+	if req.Tenant == "" {
+		return nil
+	}
+
+	var tenant Tenant
+	var ok bool
+	if c.tenants != nil {
+		tenant, ok = c.tenants.Lookup(req.Tenant)
+	}
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownTenant, req.Tenant)
+	}
+
+	if req.Organization == "" {
+		req.Organization = tenant.Organization
+	}
+	if req.Project == "" {
+		req.Project = tenant.Project
+	}
+	if req.APIKey == "" {
+		req.APIKey = tenant.APIKey
+	}
+	return nil
+}
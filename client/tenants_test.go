@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gosticks/openai-responses-api-go/models"
+)
+
+func TestTenantRequestOverrides(t *testing.T) {
+	var gotOrganization, gotProject string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotOrganization = r.Header.Get("OpenAI-Organization")
+		gotProject = r.Header.Get("OpenAI-Project")
+		io.WriteString(w, `{"id":"resp_1"}`)
+	}))
+	defer server.Close()
+
+	c := NewClient(
+		WithAPIKey("key"),
+		WithBaseURL(server.URL),
+		WithOrganization("org_default"),
+		WithProject("proj_default"),
+		WithTenantRegistry(NewTenantRegistry(map[string]Tenant{
+			"acme": {Organization: "org_acme", Project: "proj_acme"},
+		})),
+	)
+	responses := NewResponses(c)
+
+	tests := []struct {
+		name             string
+		options          []RequestOption
+		wantOrganization string
+		wantProject      string
+	}{
+		{"tenant", []RequestOption{WithTenant("acme")}, "org_acme", "proj_acme"},
+		{
+			"override equal to the client default",
+			[]RequestOption{WithTenant("acme"), WithRequestOrganization("org_default"), WithRequestProject("proj_default")},
+			"org_default", "proj_default",
+		},
+		{"no tenant", []RequestOption{WithRequestOrganization("org_other")}, "org_other", "proj_default"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := responses.Create(context.Background(), models.ResponseRequest{Model: "m"}, tt.options...); err != nil {
+				t.Fatal(err)
+			}
+			if gotOrganization != tt.wantOrganization || gotProject != tt.wantProject {
+				t.Errorf("got organization %q and project %q, want %q and %q",
+					gotOrganization, gotProject, tt.wantOrganization, tt.wantProject)
+			}
+		})
+	}
+}
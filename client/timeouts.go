@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// StreamTimeouts configures the timeouts enforced while streaming a response.
+// A zero value disables the corresponding timeout.
+type StreamTimeouts struct {
+	// Connect bounds the time from the first attempt until the stream is established and the response
+	// headers are received, including retries. Waiting for the concurrency and rate limiters before
+	// the first attempt does not count.
+	Connect time.Duration
+	// FirstEvent bounds the time between establishing the stream and receiving the first event
+	FirstEvent time.Duration
+	// Idle bounds the time between two consecutive events
+	Idle time.Duration
+}
+
+// DefaultStreamTimeouts returns the default stream timeouts. Only establishing the stream is bounded,
+// since long reasoning or tool-heavy responses may take a long time to produce events.
+func DefaultStreamTimeouts() StreamTimeouts {
+	return StreamTimeouts{
+		Connect: DefaultTimeout,
+	}
+}
+
+// WithTimeout sets the total timeout for non-streaming requests. Streams are governed by the stream timeouts.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// WithStreamTimeouts sets the connect, first event and idle timeouts for streams
+func WithStreamTimeouts(timeouts StreamTimeouts) ClientOption {
+	return func(c *Client) {
+		c.streamTimeouts = timeouts
+	}
+}
+
+// watchdog cancels a stream when the current phase does not finish within its timeout
+type watchdog struct {
+	cancel context.CancelFunc
+
+	mu    sync.Mutex
+	timer *time.Timer
+	err   error
+}
+
+// newWatchdog creates a watchdog that calls cancel when it expires
+func newWatchdog(cancel context.CancelFunc) *watchdog {
+	return &watchdog{cancel: cancel}
+}
+
+// arm restarts the watchdog for a phase that must finish within timeout.
+// A zero timeout disarms the watchdog.
+func (w *watchdog) arm(timeout time.Duration, phase string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.timer != nil {
+		w.timer.Stop()
+		w.timer = nil
+	}
+	if timeout <= 0 || w.err != nil {
+		return
+	}
+
+	w.timer = time.AfterFunc(timeout, func() {
+		w.mu.Lock()
+		w.err = fmt.Errorf("%s timed out after %s", phase, timeout)
+		w.mu.Unlock()
+		w.cancel()
+	})
+}
+
+// stop disarms the watchdog
+func (w *watchdog) stop() {
+	w.arm(0, "")
+}
+
+// expired returns a TimeoutError if the watchdog fired, or nil otherwise
+func (w *watchdog) expired() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.err == nil {
+		return nil
+	}
+	return &TimeoutError{Err: w.err}
+}
@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gosticks/openai-responses-api-go/models"
+)
+
+// newHangingStreamServer returns a server that sends a single stream event and then holds the
+// stream open until the client goes away
+func newHangingStreamServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		w.Header().Set("Content-Type", "text/event-stream")
+		io.WriteString(w, `data: {"type":"response.created","response":{"id":"resp_1","status":"in_progress"}}`+"\n\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestStreamTimeouts(t *testing.T) {
+	const timeout = 30 * time.Millisecond
+	silent := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer silent.Close()
+	stalling := newHangingStreamServer(t)
+
+	tests := []struct {
+		name     string
+		server   *httptest.Server
+		timeouts StreamTimeouts
+		events   int
+		phase    string
+	}{
+		{"first event", silent, StreamTimeouts{FirstEvent: timeout}, 0, "first stream event"},
+		{"idle", stalling, StreamTimeouts{FirstEvent: time.Minute, Idle: timeout}, 1, "stream idle"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewClient(WithAPIKey("key"), WithBaseURL(tt.server.URL), WithStreamTimeouts(tt.timeouts))
+			stream, err := NewResponses(c).CreateStream(context.Background(), models.ResponseRequest{Model: "m"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer stream.Close()
+
+			for range tt.events {
+				if _, err := stream.Recv(); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			_, err = stream.Recv()
+			if !errors.Is(err, ErrTimeout) || !strings.Contains(err.Error(), tt.phase) {
+				t.Fatalf("got error %v, want a %s timeout", err, tt.phase)
+			}
+		})
+	}
+}
+
+func TestStreamConnectTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	c := NewClient(WithAPIKey("key"), WithBaseURL(server.URL), WithStreamTimeouts(StreamTimeouts{Connect: 30 * time.Millisecond}))
+	_, err := NewResponses(c).CreateStream(context.Background(), models.ResponseRequest{Model: "m"})
+	if !errors.Is(err, ErrTimeout) {
+		t.Fatalf("got error %v, want ErrTimeout", err)
+	}
+}
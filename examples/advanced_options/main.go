@@ -25,11 +25,11 @@ func main() {
 		context.Background(),
 		openairesponses.ResponseRequest{
 			Model: "gpt-4o",
-			Input: []openairesponses.ResponseInputMessage{
+			Input: []openairesponses.InputItem{
 				openairesponses.UserInputMessage("Write a detailed essay about artificial intelligence."),
 			},
 			// Limit the response to 100 tokens
-			MaxOutputTokens: 100,
+			MaxOutputTokens: openairesponses.Int(100),
 		},
 	)
 	if err != nil {
@@ -49,7 +49,7 @@ func main() {
 		context.Background(),
 		openairesponses.ResponseRequest{
 			Model: "gpt-4o",
-			Input: []openairesponses.ResponseInputMessage{
+			Input: []openairesponses.InputItem{
 				openairesponses.UserInputMessage("Tell me a joke."),
 			},
 			// Set custom instructions
@@ -76,7 +76,7 @@ func main() {
 		context.Background(),
 		openairesponses.ResponseRequest{
 			Model: "gpt-4o",
-			Input: []openairesponses.ResponseInputMessage{
+			Input: []openairesponses.InputItem{
 				openairesponses.UserInputMessage("What's the capital of France?"),
 			},
 		},
@@ -96,7 +96,7 @@ func main() {
 		context.Background(),
 		openairesponses.ResponseRequest{
 			Model: "gpt-4o",
-			Input: []openairesponses.ResponseInputMessage{
+			Input: []openairesponses.InputItem{
 				openairesponses.UserInputMessage("What's the population of that city?"),
 			},
 			// Use the previous response ID to continue the conversation
@@ -118,7 +118,7 @@ func main() {
 		context.Background(),
 		openairesponses.ResponseRequest{
 			Model: "gpt-4o",
-			Input: []openairesponses.ResponseInputMessage{
+			Input: []openairesponses.InputItem{
 				openairesponses.UserInputMessage("Tell me more interesting facts about this city."),
 			},
 			// Use the previous response ID to continue the conversation
@@ -141,8 +141,8 @@ func main() {
 func printUsage(resp *openairesponses.ResponseResponse) {
 	if resp.Usage != nil {
 		fmt.Printf("\nUsage information:\n")
-		fmt.Printf("  Prompt tokens: %d\n", resp.Usage.PromptTokens)
-		fmt.Printf("  Completion tokens: %d\n", resp.Usage.CompletionTokens)
+		fmt.Printf("  Input tokens: %d\n", resp.Usage.InputTokens)
+		fmt.Printf("  Output tokens: %d\n", resp.Usage.OutputTokens)
 		fmt.Printf("  Total tokens: %d\n", resp.Usage.TotalTokens)
 	}
 }
\ No newline at end of file
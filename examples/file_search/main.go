@@ -25,7 +25,7 @@ func main() {
 		context.Background(),
 		openairesponses.ResponseRequest{
 			Model: "gpt-4o",
-			Input: []openairesponses.ResponseInputMessage{
+			Input: []openairesponses.InputItem{
 				openairesponses.UserInputMessage("What are the attributes of an ancient brown dragon?"),
 			},
 			Tools: []openairesponses.ResponseTool{
@@ -51,7 +51,7 @@ func main() {
 		context.Background(),
 		openairesponses.ResponseRequest{
 			Model: "gpt-4o",
-			Input: []openairesponses.ResponseInputMessage{
+			Input: []openairesponses.InputItem{
 				openairesponses.UserInputMessage("Find information about climate change in my documents."),
 			},
 			Tools: []openairesponses.ResponseTool{
@@ -74,8 +74,8 @@ func main() {
 func printUsage(resp *openairesponses.ResponseResponse) {
 	if resp.Usage != nil {
 		fmt.Printf("\nUsage information:\n")
-		fmt.Printf("  Prompt tokens: %d\n", resp.Usage.PromptTokens)
-		fmt.Printf("  Completion tokens: %d\n", resp.Usage.CompletionTokens)
+		fmt.Printf("  Input tokens: %d\n", resp.Usage.InputTokens)
+		fmt.Printf("  Output tokens: %d\n", resp.Usage.OutputTokens)
 		fmt.Printf("  Total tokens: %d\n", resp.Usage.TotalTokens)
 	}
 }
\ No newline at end of file
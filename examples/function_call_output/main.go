@@ -58,7 +58,7 @@ func main() {
 	}
 
 	// Create initial messages
-	input := []openairesponses.ResponseInputMessage{
+	input := []openairesponses.InputItem{
 		openairesponses.DeveloperInputMessage("You are a helpful assistant with access to weather information."),
 		openairesponses.UserInputMessage("What's the weather like in San Francisco and how does it compare to New York?"),
 	}
@@ -71,7 +71,7 @@ func main() {
 			Model:  "gpt-4o",
 			Input:  input,
 			Tools:  tools,
-			Store:  true,
+			Store:  openairesponses.Bool(true),
 		},
 	)
 	if err != nil {
@@ -87,7 +87,7 @@ func main() {
 		fmt.Println("\nProcessing tool calls...")
 
 		// Create a new input array that includes the previous conversation
-		newInput := make([]openairesponses.ResponseInputMessage, len(input))
+		newInput := make([]openairesponses.InputItem, len(input))
 		copy(newInput, input)
 
 		// Process each tool call
@@ -130,7 +130,7 @@ func main() {
 				Model:  "gpt-4o",
 				Input:  newInput,
 				Tools:  tools,
-				Store:  true,
+				Store:  openairesponses.Bool(true),
 			},
 		)
 		if err != nil {
@@ -144,8 +144,8 @@ func main() {
 		// Print usage information if available
 		if resp2.Usage != nil {
 			fmt.Printf("\nFollow-up usage information:\n")
-			fmt.Printf("  Prompt tokens: %d\n", resp2.Usage.PromptTokens)
-			fmt.Printf("  Completion tokens: %d\n", resp2.Usage.CompletionTokens)
+			fmt.Printf("  Input tokens: %d\n", resp2.Usage.InputTokens)
+			fmt.Printf("  Output tokens: %d\n", resp2.Usage.OutputTokens)
 			fmt.Printf("  Total tokens: %d\n", resp2.Usage.TotalTokens)
 		}
 	}
@@ -153,8 +153,8 @@ func main() {
 	// Print usage information if available
 	if resp1.Usage != nil {
 		fmt.Printf("\nInitial usage information:\n")
-		fmt.Printf("  Prompt tokens: %d\n", resp1.Usage.PromptTokens)
-		fmt.Printf("  Completion tokens: %d\n", resp1.Usage.CompletionTokens)
+		fmt.Printf("  Input tokens: %d\n", resp1.Usage.InputTokens)
+		fmt.Printf("  Output tokens: %d\n", resp1.Usage.OutputTokens)
 		fmt.Printf("  Total tokens: %d\n", resp1.Usage.TotalTokens)
 	}
 }
\ No newline at end of file
@@ -35,7 +35,7 @@ func main() {
 	// Create a request with a function tool
 	req := openairesponses.ResponseRequest{
 		Model: "gpt-4o",
-		Input: []openairesponses.ResponseInputMessage{
+		Input: []openairesponses.InputItem{
 			openairesponses.UserInputMessage("What's the weather in New York?"),
 		},
 		Tools: []openairesponses.ResponseTool{
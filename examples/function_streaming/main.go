@@ -42,7 +42,7 @@ func main() {
 		Type:          "file_search",
 		Description:   "Search through files to find relevant information",
 		VectorStoreIDs: []string{"default_store"},
-		MaxNumResults: 3,
+		MaxNumResults: models.Int(3),
 	}
 
 	// Define the query prompt - specifically designed to trigger both tool types
@@ -55,11 +55,8 @@ func main() {
 	// Create a request
 	req := models.ResponseRequest{
 		Model: "gpt-4o",
-		Input: []models.ResponseInputMessage{
-			{
-				Role:    "user",
-				Content: userPrompt,
-			},
+		Input: []models.InputItem{
+			models.UserInputMessage(userPrompt),
 		},
 		Tools:  []models.ResponseTool{weatherTool, fileSearchTool},
 		Stream: true,
@@ -136,9 +133,9 @@ func printStreamingUpdate(chunk *models.ResponseStreamResponse) {
 
 	// Print usage information when available
 	if chunk.Usage != nil {
-		fmt.Printf("\n[Usage - Prompt: %d, Completion: %d, Total: %d]",
-			chunk.Usage.PromptTokens,
-			chunk.Usage.CompletionTokens,
+		fmt.Printf("\n[Usage - Input: %d, Output: %d, Total: %d]",
+			chunk.Usage.InputTokens,
+			chunk.Usage.OutputTokens,
 			chunk.Usage.TotalTokens)
 	}
 
@@ -25,7 +25,7 @@ func main() {
 		context.Background(),
 		openairesponses.ResponseRequest{
 			Model: "gpt-4o",
-			Input: []openairesponses.ResponseInputMessage{
+			Input: []openairesponses.InputItem{
 				openairesponses.DeveloperInputMessage("You are a helpful assistant."),
 				openairesponses.UserInputMessage("Write a short poem about programming."),
 			},
@@ -83,8 +83,8 @@ func main() {
 	// Print the usage information if available
 	if resp.Usage != nil {
 		fmt.Printf("\nUsage information:\n")
-		fmt.Printf("  Prompt tokens: %d\n", resp.Usage.PromptTokens)
-		fmt.Printf("  Completion tokens: %d\n", resp.Usage.CompletionTokens)
+		fmt.Printf("  Input tokens: %d\n", resp.Usage.InputTokens)
+		fmt.Printf("  Output tokens: %d\n", resp.Usage.OutputTokens)
 		fmt.Printf("  Total tokens: %d\n", resp.Usage.TotalTokens)
 	}
 }
\ No newline at end of file
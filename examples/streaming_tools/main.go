@@ -55,7 +55,7 @@ func main() {
 		context.Background(),
 		openairesponses.ResponseRequest{
 			Model: "gpt-4o",
-			Input: []openairesponses.ResponseInputMessage{
+			Input: []openairesponses.InputItem{
 				openairesponses.DeveloperInputMessage("You are a helpful assistant with access to weather information."),
 				openairesponses.UserInputMessage("What's the weather like in San Francisco?"),
 			},
@@ -144,7 +144,7 @@ func main() {
 					context.Background(),
 					openairesponses.ResponseRequest{
 						Model: "gpt-4o",
-						Input: []openairesponses.ResponseInputMessage{
+						Input: []openairesponses.InputItem{
 							// Only need to provide the new user message and tool result
 							openairesponses.SystemInputMessage(fmt.Sprintf("Function get_weather returned: %s", result)),
 						},
@@ -194,7 +194,7 @@ func main() {
 					context.Background(),
 					openairesponses.ResponseRequest{
 						Model: "gpt-4o",
-						Input: []openairesponses.ResponseInputMessage{
+						Input: []openairesponses.InputItem{
 							openairesponses.UserInputMessage("How does that compare to the weather in New York?"),
 						},
 						// Use the previous response ID to continue the conversation
@@ -283,7 +283,7 @@ func main() {
 								context.Background(),
 								openairesponses.ResponseRequest{
 									Model: "gpt-4o",
-									Input: []openairesponses.ResponseInputMessage{
+									Input: []openairesponses.InputItem{
 										openairesponses.SystemInputMessage(fmt.Sprintf("Function get_weather returned: %s", result)),
 									},
 									// Use the previous response ID to continue the conversation
@@ -329,8 +329,8 @@ func main() {
 							// Print usage information if available
 							if finalResp.Usage != nil {
 								fmt.Printf("\n\nFinal usage information:\n")
-								fmt.Printf("  Prompt tokens: %d\n", finalResp.Usage.PromptTokens)
-								fmt.Printf("  Completion tokens: %d\n", finalResp.Usage.CompletionTokens)
+								fmt.Printf("  Input tokens: %d\n", finalResp.Usage.InputTokens)
+								fmt.Printf("  Output tokens: %d\n", finalResp.Usage.OutputTokens)
 								fmt.Printf("  Total tokens: %d\n", finalResp.Usage.TotalTokens)
 							}
 						}
@@ -340,16 +340,16 @@ func main() {
 				// Print usage information if available
 				if questionResp.Usage != nil {
 					fmt.Printf("\nFollow-up question usage information:\n")
-					fmt.Printf("  Prompt tokens: %d\n", questionResp.Usage.PromptTokens)
-					fmt.Printf("  Completion tokens: %d\n", questionResp.Usage.CompletionTokens)
+					fmt.Printf("  Input tokens: %d\n", questionResp.Usage.InputTokens)
+					fmt.Printf("  Output tokens: %d\n", questionResp.Usage.OutputTokens)
 					fmt.Printf("  Total tokens: %d\n", questionResp.Usage.TotalTokens)
 				}
 
 				// Print usage information if available
 				if followUpResp.Usage != nil {
 					fmt.Printf("\nFollow-up usage information:\n")
-					fmt.Printf("  Prompt tokens: %d\n", followUpResp.Usage.PromptTokens)
-					fmt.Printf("  Completion tokens: %d\n", followUpResp.Usage.CompletionTokens)
+					fmt.Printf("  Input tokens: %d\n", followUpResp.Usage.InputTokens)
+					fmt.Printf("  Output tokens: %d\n", followUpResp.Usage.OutputTokens)
 					fmt.Printf("  Total tokens: %d\n", followUpResp.Usage.TotalTokens)
 				}
 			}
@@ -359,8 +359,8 @@ func main() {
 	// Print usage information if available
 	if resp.Usage != nil {
 		fmt.Printf("\nInitial usage information:\n")
-		fmt.Printf("  Prompt tokens: %d\n", resp.Usage.PromptTokens)
-		fmt.Printf("  Completion tokens: %d\n", resp.Usage.CompletionTokens)
+		fmt.Printf("  Input tokens: %d\n", resp.Usage.InputTokens)
+		fmt.Printf("  Output tokens: %d\n", resp.Usage.OutputTokens)
 		fmt.Printf("  Total tokens: %d\n", resp.Usage.TotalTokens)
 	}
 }
\ No newline at end of file
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	openairesponses "github.com/gosticks/openai-responses-api-go"
+)
+
+// printTracer is a minimal Tracer that prints spans to stdout.
+// An OpenTelemetry adapter has the same shape: Start calls otelTracer.Start and wraps the
+// returned trace.Span, converting each Attribute into an attribute.KeyValue.
+type printTracer struct{}
+
+// Start starts a span
+func (printTracer) Start(ctx context.Context, name string, attrs ...openairesponses.Attribute) (context.Context, openairesponses.Span) {
+	fmt.Printf("[span start] %s %v\n", name, attrs)
+	return ctx, &printSpan{name: name, start: time.Now()}
+}
+
+// printSpan prints span events and attributes
+type printSpan struct {
+	name  string
+	start time.Time
+}
+
+// SetAttributes maps to trace.Span.SetAttributes
+func (s *printSpan) SetAttributes(attrs ...openairesponses.Attribute) {
+	fmt.Printf("[span attributes] %s %v\n", s.name, attrs)
+}
+
+// AddEvent maps to trace.Span.AddEvent
+func (s *printSpan) AddEvent(name string, attrs ...openairesponses.Attribute) {
+	fmt.Printf("[span event] %s %s %v\n", s.name, name, attrs)
+}
+
+// RecordError maps to trace.Span.RecordError and trace.Span.SetStatus(codes.Error, ...)
+func (s *printSpan) RecordError(err error) {
+	fmt.Printf("[span error] %s %v\n", s.name, err)
+}
+
+// End maps to trace.Span.End
+func (s *printSpan) End() {
+	fmt.Printf("[span end] %s after %s\n", s.name, time.Since(s.start))
+}
+
+// printMeter is a minimal Meter that prints metrics to stdout.
+// An OpenTelemetry adapter creates a Float64Histogram per metric name and calls Record.
+type printMeter struct{}
+
+// RecordHistogram records a histogram value
+func (printMeter) RecordHistogram(ctx context.Context, name string, value float64, attrs ...openairesponses.Attribute) {
+	fmt.Printf("[metric] %s=%g %v\n", name, value, attrs)
+}
+
+func main() {
+	// Get API key from environment variable
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("OPENAI_API_KEY environment variable is not set")
+		os.Exit(1)
+	}
+
+	// Create a new client with tracing and metrics
+	client := openairesponses.NewClient(apiKey,
+		openairesponses.WithTracer(printTracer{}),
+		openairesponses.WithMeter(printMeter{}),
+	)
+
+	// Create a new streaming response
+	stream, err := client.Responses.CreateStream(
+		context.Background(),
+		openairesponses.ResponseRequest{
+			Model: "gpt-4o",
+			Input: []openairesponses.InputItem{
+				openairesponses.UserInputMessage("Write a haiku about observability."),
+			},
+		},
+	)
+	if err != nil {
+		fmt.Printf("Error creating streaming response: %v\n", err)
+		os.Exit(1)
+	}
+	defer stream.Close()
+
+	// Read the stream, the span ends when the stream completes
+	for {
+		chunk, err := stream.Recv()
+		if err != nil {
+			break
+		}
+		for _, choice := range chunk.Choices {
+			fmt.Print(choice.Delta.Content)
+		}
+	}
+	fmt.Println()
+}
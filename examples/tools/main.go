@@ -53,7 +53,7 @@ func main() {
 		context.Background(),
 		openairesponses.ResponseRequest{
 			Model: "gpt-4o",
-			Input: []openairesponses.ResponseInputMessage{
+			Input: []openairesponses.InputItem{
 				openairesponses.DeveloperInputMessage("You are a helpful assistant."),
 				openairesponses.UserInputMessage("What's the weather like in San Francisco?"),
 			},
@@ -95,7 +95,7 @@ func main() {
 				context.Background(),
 				openairesponses.ResponseRequest{
 					Model: "gpt-4o",
-					Input: []openairesponses.ResponseInputMessage{
+					Input: []openairesponses.InputItem{
 						openairesponses.DeveloperInputMessage("You are a helpful assistant."),
 						openairesponses.UserInputMessage("What's the weather like in San Francisco?"),
 					},
@@ -118,8 +118,8 @@ func main() {
 	// Print the usage information if available
 	if resp.Usage != nil {
 		fmt.Printf("\nUsage information:\n")
-		fmt.Printf("  Prompt tokens: %d\n", resp.Usage.PromptTokens)
-		fmt.Printf("  Completion tokens: %d\n", resp.Usage.CompletionTokens)
+		fmt.Printf("  Input tokens: %d\n", resp.Usage.InputTokens)
+		fmt.Printf("  Output tokens: %d\n", resp.Usage.OutputTokens)
 		fmt.Printf("  Total tokens: %d\n", resp.Usage.TotalTokens)
 	}
 }
\ No newline at end of file
@@ -0,0 +1,288 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// Input item types of the Responses API
+const (
+	InputItemMessage            = "message"
+	InputItemFunctionCall       = "function_call"
+	InputItemFunctionCallOutput = "function_call_output"
+	InputItemReference          = "item_reference"
+	InputItemReasoning          = "reasoning"
+)
+
+// Content part types of input messages
+const (
+	InputContentText  = "input_text"
+	InputContentImage = "input_image"
+	InputContentFile  = "input_file"
+)
+
+// InputItem is an item of the input array of a request. The input item types of this package are
+// ResponseInputMessage, InputMessage, FunctionCallItem, FunctionCallOutputItem, ItemReference and
+// ReasoningItem. OutputMessage is an input item too, so that output can be sent back as input.
+type InputItem interface {
+	// InputItemType returns the type of the item, e.g. "message"
+	InputItemType() string
+}
+
+// InputItems is the input array of a request
+type InputItems []InputItem
+
+// InputMessage is a message with content parts such as text, images and files
+type InputMessage struct {
+	ID      string         `json:"id,omitempty"`
+	Role    string         `json:"role"`
+	Status  string         `json:"status,omitempty"`
+	Content []InputContent `json:"content"`
+}
+
+// InputContent is a content part of an input message
+type InputContent struct {
+	Type string `json:"type"`
+	// Text is the text of an input_text part
+	Text string `json:"text,omitempty"`
+	// ImageURL is the URL or data URL of an input_image part
+	ImageURL string `json:"image_url,omitempty"`
+	// Detail is the detail level of an input_image part: "low", "high" or "auto"
+	Detail string `json:"detail,omitempty"`
+	// FileID is the ID of an uploaded file of an input_image or input_file part
+	FileID string `json:"file_id,omitempty"`
+	// FileURL is the URL of an input_file part
+	FileURL string `json:"file_url,omitempty"`
+	// Filename is the name of the file of an input_file part with inline data
+	Filename string `json:"filename,omitempty"`
+	// FileData is the data URL of an input_file part with inline data
+	FileData string `json:"file_data,omitempty"`
+}
+
+// FunctionCallOutputItem is the output of a function call, sent back to the model
+type FunctionCallOutputItem struct {
+	ID     string `json:"id,omitempty"`
+	CallID string `json:"call_id"`
+	Output string `json:"output"`
+	Status string `json:"status,omitempty"`
+}
+
+// ItemReference refers to an item of a previous response by its ID
+type ItemReference struct {
+	ID string `json:"id"`
+}
+
+// InputItemType returns the type of the item
+func (m ResponseInputMessage) InputItemType() string {
+	if m.Type != "" {
+		return m.Type
+	}
+	return InputItemMessage
+}
+
+// InputItemType returns the type of the item
+func (InputMessage) InputItemType() string { return InputItemMessage }
+
+// InputItemType returns the type of the item
+func (FunctionCallOutputItem) InputItemType() string { return InputItemFunctionCallOutput }
+
+// InputItemType returns the type of the item
+func (ItemReference) InputItemType() string { return InputItemReference }
+
+// InputItemType returns the type of the item
+func (OutputMessage) InputItemType() string { return InputItemMessage }
+
+// InputItemType returns the type of the item
+func (FunctionCallItem) InputItemType() string { return InputItemFunctionCall }
+
+// InputItemType returns the type of the item
+func (ReasoningItem) InputItemType() string { return InputItemReasoning }
+
+// InputItemType returns the type of the item
+func (i UnknownOutputItem) InputItemType() string { return i.Type }
+
+// MarshalJSON encodes the item with its type
+func (i InputMessage) MarshalJSON() ([]byte, error) {
+	type alias InputMessage
+	return marshalWithType(i.InputItemType(), alias(i))
+}
+
+// MarshalJSON encodes the item with its type
+func (i FunctionCallOutputItem) MarshalJSON() ([]byte, error) {
+	type alias FunctionCallOutputItem
+	return marshalWithType(i.InputItemType(), alias(i))
+}
+
+// MarshalJSON encodes the item with its type
+func (i ItemReference) MarshalJSON() ([]byte, error) {
+	type alias ItemReference
+	return marshalWithType(i.InputItemType(), alias(i))
+}
+
+// UnmarshalInputItem decodes a single input item into the item type matching its type field. Messages
+// with string content decode into *ResponseInputMessage, messages with content parts into *InputMessage,
+// and items of other types into *UnknownOutputItem.
+func UnmarshalInputItem(data []byte) (InputItem, error) {
+	var header struct {
+		Type    string          `json:"type"`
+		Content json.RawMessage `json:"content"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, err
+	}
+
+	var item InputItem
+	switch header.Type {
+	case "", InputItemMessage:
+		if len(header.Content) > 0 && header.Content[0] == '[' {
+			item = &InputMessage{}
+		} else {
+			item = &ResponseInputMessage{}
+		}
+	case InputItemFunctionCall:
+		item = &FunctionCallItem{}
+	case InputItemFunctionCallOutput:
+		item = &FunctionCallOutputItem{}
+	case InputItemReference:
+		item = &ItemReference{}
+	case InputItemReasoning:
+		item = &ReasoningItem{}
+	default:
+		return &UnknownOutputItem{Type: header.Type, Raw: append(json.RawMessage{}, data...)}, nil
+	}
+
+	if err := json.Unmarshal(data, item); err != nil {
+		return nil, fmt.Errorf("decoding %s input item: %w", header.Type, err)
+	}
+	return item, nil
+}
+
+// UnmarshalJSON decodes every item into the item type matching its type field
+func (items *InputItems) UnmarshalJSON(data []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	decoded := make(InputItems, 0, len(raw))
+	for _, itemData := range raw {
+		item, err := UnmarshalInputItem(itemData)
+		if err != nil {
+			return err
+		}
+		decoded = append(decoded, item)
+	}
+	*items = decoded
+	return nil
+}
+
+// AsInput returns the output items as input items, so that they can be sent back to the model in the
+// next request, e.g. when responses are not stored. Items that are no input item type of this package
+// are carried over as their raw JSON.
+func (items OutputItems) AsInput() (InputItems, error) {
+	input := make(InputItems, 0, len(items))
+	for _, item := range items {
+		if inputItem, ok := item.(InputItem); ok {
+			input = append(input, inputItem)
+			continue
+		}
+		data, err := json.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+		input = append(input, &UnknownOutputItem{Type: item.OutputItemType(), Raw: data})
+	}
+	return input, nil
+}
+
+// NewInputMessage creates a new input message with the given role and content parts
+func NewInputMessage(role string, parts ...InputContent) InputMessage {
+	return InputMessage{
+		Role:    role,
+		Content: parts,
+	}
+}
+
+// InputText creates a new text content part
+func InputText(text string) InputContent {
+	return InputContent{
+		Type: InputContentText,
+		Text: text,
+	}
+}
+
+// InputImageURL creates a new image content part from a URL or data URL
+func InputImageURL(url string) InputContent {
+	return InputContent{
+		Type:     InputContentImage,
+		ImageURL: url,
+		Detail:   "auto",
+	}
+}
+
+// InputImageData creates a new image content part from the image data
+func InputImageData(mimeType string, data []byte) InputContent {
+	return InputImageURL(dataURL(mimeType, data))
+}
+
+// InputImageFile creates a new image content part from an uploaded file
+func InputImageFile(fileID string) InputContent {
+	return InputContent{
+		Type:   InputContentImage,
+		FileID: fileID,
+		Detail: "auto",
+	}
+}
+
+// InputFile creates a new file content part from an uploaded file
+func InputFile(fileID string) InputContent {
+	return InputContent{
+		Type:   InputContentFile,
+		FileID: fileID,
+	}
+}
+
+// InputFileURL creates a new file content part from a URL
+func InputFileURL(url string) InputContent {
+	return InputContent{
+		Type:    InputContentFile,
+		FileURL: url,
+	}
+}
+
+// InputFileData creates a new file content part from the file data
+func InputFileData(filename, mimeType string, data []byte) InputContent {
+	return InputContent{
+		Type:     InputContentFile,
+		Filename: filename,
+		FileData: dataURL(mimeType, data),
+	}
+}
+
+// FunctionCallInput creates a new function call item, e.g. to replay a call made by the model
+func FunctionCallInput(callID, name, arguments string) FunctionCallItem {
+	return FunctionCallItem{
+		CallID:    callID,
+		Name:      name,
+		Arguments: arguments,
+	}
+}
+
+// FunctionCallOutput creates a new function call output item
+func FunctionCallOutput(callID, output string) FunctionCallOutputItem {
+	return FunctionCallOutputItem{
+		CallID: callID,
+		Output: output,
+	}
+}
+
+// NewItemReference creates a new reference to an item of a previous response
+func NewItemReference(id string) ItemReference {
+	return ItemReference{ID: id}
+}
+
+// dataURL encodes data as a base64 data URL
+func dataURL(mimeType string, data []byte) string {
+	return "data:" + mimeType + ";base64," + base64.StdEncoding.EncodeToString(data)
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"net/http"
+	"time"
+)
+
+// ResponseMetadata represents the HTTP level details of an API response
+type ResponseMetadata struct {
+	// StatusCode is the HTTP status code of the response
+	StatusCode int `json:"status_code"`
+	// Header holds the HTTP response headers
+	Header http.Header `json:"header,omitempty"`
+	// RequestID is the x-request-id assigned by the API, useful for support requests
+	RequestID string `json:"request_id,omitempty"`
+	// ProcessingTime is the server-side processing time reported in the openai-processing-ms header
+	ProcessingTime time.Duration `json:"processing_time,omitempty"`
+	// Latency is the client-side time until the response headers were received, including retries
+	Latency time.Duration `json:"latency,omitempty"`
+	// QueueWait is the time the call waited for a slot of the client concurrency limiter
+	QueueWait time.Duration `json:"queue_wait,omitempty"`
+	// HedgeAttempt is the 1-based attempt that won a hedged call, or 0 if the call was not hedged
+	HedgeAttempt int `json:"hedge_attempt,omitempty"`
+	// HedgeAttempts is the number of attempts sent for a hedged call
+	HedgeAttempts int `json:"hedge_attempts,omitempty"`
+}
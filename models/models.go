@@ -1,12 +1,56 @@
 package models
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // Usage represents the usage statistics for an API request
 type Usage struct {
-	PromptTokens     int `json:"prompt_tokens"`
-	CompletionTokens int `json:"completion_tokens"`
-	TotalTokens      int `json:"total_tokens"`
+	InputTokens         int                  `json:"input_tokens"`
+	OutputTokens        int                  `json:"output_tokens"`
+	TotalTokens         int                  `json:"total_tokens"`
+	OutputTokensDetails *OutputTokensDetails `json:"output_tokens_details,omitempty"`
+	// PromptTokens is the Chat Completions name of InputTokens. Both are set when decoding either.
+	PromptTokens int `json:"prompt_tokens,omitempty"`
+	// CompletionTokens is the Chat Completions name of OutputTokens. Both are set when decoding either.
+	CompletionTokens int `json:"completion_tokens,omitempty"`
+}
+
+// OutputTokensDetails breaks down the output tokens
+type OutputTokensDetails struct {
+	// ReasoningTokens is the number of output tokens spent on reasoning
+	ReasoningTokens int `json:"reasoning_tokens"`
+}
+
+// UnmarshalJSON decodes the usage, filling in the Responses and the Chat Completions token counts from
+// each other
+func (u *Usage) UnmarshalJSON(data []byte) error {
+	type alias Usage
+	if err := json.Unmarshal(data, (*alias)(u)); err != nil {
+		return err
+	}
+	if u.InputTokens == 0 {
+		u.InputTokens = u.PromptTokens
+	}
+	if u.OutputTokens == 0 {
+		u.OutputTokens = u.CompletionTokens
+	}
+	if u.PromptTokens == 0 {
+		u.PromptTokens = u.InputTokens
+	}
+	if u.CompletionTokens == 0 {
+		u.CompletionTokens = u.OutputTokens
+	}
+	return nil
+}
+
+// ReasoningTokens returns the number of output tokens spent on reasoning
+func (u *Usage) ReasoningTokens() int {
+	if u == nil || u.OutputTokensDetails == nil {
+		return 0
+	}
+	return u.OutputTokensDetails.ReasoningTokens
 }
 
 // ResponseMessage represents a message in a response
@@ -23,7 +67,7 @@ type ResponseTool struct {
 	Parameters     any                  `json:"parameters,omitempty"`
 	Function       *ResponseToolFunction `json:"function,omitempty"`
 	VectorStoreIDs []string             `json:"vector_store_ids,omitempty"`
-	MaxNumResults  int                  `json:"max_num_results,omitempty"`
+	MaxNumResults  Opt[int]             `json:"max_num_results,omitzero"`
 }
 
 // ResponseToolFunction represents a function definition for a tool
@@ -53,7 +97,23 @@ type ResponseChoice struct {
 	ToolCalls    []ResponseToolCall `json:"tool_calls,omitempty"`
 }
 
-// ResponseInputMessage represents a message in the input field
+// FinishReason maps the status of a response to a Chat Completions style finish reason: "tool_calls"
+// if the model called a function, "length" if the response is incomplete and "stop" if it completed.
+// Other statuses have no finish reason.
+func FinishReason(status string, toolCalls bool) string {
+	switch {
+	case toolCalls:
+		return "tool_calls"
+	case status == "incomplete":
+		return "length"
+	case status == "completed":
+		return "stop"
+	}
+	return ""
+}
+
+// ResponseInputMessage represents a message with text content in the input field. It also represents
+// a function call output when Type is "function_call_output".
 type ResponseInputMessage struct {
 	Role     string `json:"role,omitempty"`
 	Content  string `json:"content,omitempty"`
@@ -68,32 +128,39 @@ type ResponseRequest struct {
 	Model string `json:"model"`
 	// Messages is the list of messages to send to the model (deprecated, use Input instead)
 	Messages []ResponseMessage `json:"messages,omitempty"`
-	// Input is the list of messages to send to the model
-	Input []ResponseInputMessage `json:"input"`
+	// Input is the list of input items to send to the model, such as messages and function call outputs
+	Input InputItems `json:"input"`
 	// Tools is the list of tools the model can use
 	Tools []ResponseTool `json:"tools,omitempty"`
 	// ToolChoice is the tool choice for the model
 	ToolChoice any `json:"tool_choice,omitempty"`
 	// Temperature is the sampling temperature to use
-	Temperature float32 `json:"temperature,omitempty"`
+	Temperature Opt[float64] `json:"temperature,omitzero"`
 	// TopP is the nucleus sampling parameter
-	TopP float32 `json:"top_p,omitempty"`
+	TopP Opt[float64] `json:"top_p,omitzero"`
 	// N is the number of responses to generate
-	N int `json:"n,omitempty"`
+	N Opt[int] `json:"n,omitzero"`
 	// Stream indicates whether to stream the response
 	Stream bool `json:"stream,omitempty"`
 	// MaxTokens is the maximum number of tokens to generate (deprecated, use MaxOutputTokens instead)
-	MaxTokens int `json:"max_tokens,omitempty"`
+	MaxTokens Opt[int] `json:"max_tokens,omitzero"`
 	// MaxOutputTokens is an upper bound for the number of tokens that can be generated for a response
-	MaxOutputTokens int `json:"max_output_tokens,omitempty"`
+	MaxOutputTokens Opt[int] `json:"max_output_tokens,omitzero"`
 	// PreviousResponseID is the unique ID of the previous response to the model, used for multi-turn conversations
 	PreviousResponseID string `json:"previous_response_id,omitempty"`
 	// Instructions inserts a system (or developer) message as the first item in the model's context
 	Instructions string `json:"instructions,omitempty"`
+	// Reasoning configures the reasoning effort and summary of reasoning models
+	Reasoning *Reasoning `json:"reasoning,omitempty"`
+	// Include lists additional output data to include, such as IncludeReasoningEncryptedContent
+	Include []string `json:"include,omitempty"`
+	// Text configures the text output, such as structured outputs following a JSON schema
+	Text *TextConfig `json:"text,omitempty"`
 	// User is the user ID for the request
 	User string `json:"user,omitempty"`
-	// Store indicates whether to store the response in the system
-	Store bool `json:"store,omitempty"`
+	// Store indicates whether to store the response in the system. The server stores responses
+	// unless store is explicitly set to false.
+	Store Opt[bool] `json:"store,omitzero"`
 }
 
 // ResponseResponse represents a response from the Responses API
@@ -102,19 +169,35 @@ type ResponseResponse struct {
 	Object     string           `json:"object"`
 	Created    int64            `json:"created"`
 	Model      string           `json:"model"`
+	CreatedAt  int64            `json:"created_at,omitempty"`
+	Status     string           `json:"status,omitempty"`
+	Output     OutputItems      `json:"output,omitempty"`
 	Choices    []ResponseChoice `json:"choices"`
 	Usage      *Usage           `json:"usage,omitempty"`
 	OutputText string           `json:"output_text,omitempty"` // Alias for first choice's content
+	// RateLimit is the rate limit state reported by the API for this request
+	RateLimit *RateLimitInfo `json:"-"`
+	// Metadata holds the HTTP response details such as the request ID
+	Metadata *ResponseMetadata `json:"-"`
 }
 
-// GetOutputText returns the content of the first choice's message
+// GetOutputText returns the text of all output_text parts of the output, or the content of the first
+// choice's message if the response has no output
 func (r ResponseResponse) GetOutputText() string {
+	if len(r.Output) > 0 {
+		return r.Output.OutputText()
+	}
 	if len(r.Choices) == 0 || r.Choices[0].Message.Content == "" {
 		return ""
 	}
 	return r.Choices[0].Message.Content
 }
 
+// FunctionCalls returns the function calls in the output
+func (r ResponseResponse) FunctionCalls() []FunctionCallItem {
+	return r.Output.FunctionCalls()
+}
+
 // ResponseStreamChoice represents a choice in a streaming response
 type ResponseStreamChoice struct {
 	Index        int                 `json:"index"`
@@ -127,6 +210,8 @@ type ResponseStreamDelta struct {
 	Role      string             `json:"role,omitempty"`
 	Content   string             `json:"content,omitempty"`
 	ToolCalls []ResponseToolCall `json:"tool_calls,omitempty"`
+	// ReasoningSummary is a delta of the reasoning summary text
+	ReasoningSummary string `json:"reasoning_summary,omitempty"`
 }
 
 // ResponseStreamResponse represents a streaming response from the Responses API
@@ -137,6 +222,8 @@ type ResponseStreamResponse struct {
 	Model   string                 `json:"model"`
 	Choices []ResponseStreamChoice `json:"choices"`
 	Usage   *Usage                 `json:"usage,omitempty"`
+	// Item is the completed output item of a response.output_item.done event, e.g. a *ReasoningItem
+	Item OutputItem `json:"-"`
 }
 
 // ResponseState represents the state of a response
@@ -158,6 +245,10 @@ type ResponseStateResponse struct {
 	Object    string            `json:"object"`
 	CreatedAt time.Time         `json:"created_at"`
 	Messages  []ResponseMessage `json:"messages"`
+	// RateLimit is the rate limit state reported by the API for this request
+	RateLimit *RateLimitInfo `json:"-"`
+	// Metadata holds the HTTP response details such as the request ID
+	Metadata *ResponseMetadata `json:"-"`
 }
 
 // WebSearchTool represents the web search tool
@@ -169,7 +260,7 @@ type WebSearchTool struct {
 type FileSearchTool struct {
 	Type           string   `json:"type"`
 	VectorStoreIDs []string `json:"vector_store_ids,omitempty"`
-	MaxNumResults  int      `json:"max_num_results,omitempty"`
+	MaxNumResults  Opt[int] `json:"max_num_results,omitzero"`
 }
 
 // ComputerUseTool represents the computer use tool
@@ -184,13 +275,16 @@ func NewWebSearchTool() ResponseTool {
 	}
 }
 
-// NewFileSearchTool creates a new file search tool
+// NewFileSearchTool creates a new file search tool. A maxNumResults of 0 keeps the server default.
 func NewFileSearchTool(vectorStoreIDs []string, maxNumResults int) ResponseTool {
-	return ResponseTool{
+	tool := ResponseTool{
 		Type:           "file_search",
 		VectorStoreIDs: vectorStoreIDs,
-		MaxNumResults:  maxNumResults,
 	}
+	if maxNumResults > 0 {
+		tool.MaxNumResults = Int(maxNumResults)
+	}
+	return tool
 }
 
 // NewFileSearchToolWithIDs creates a new file search tool with just vector store IDs
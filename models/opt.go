@@ -0,0 +1,85 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Opt is an optional request parameter. Unlike a plain value with omitempty, it distinguishes an unset
+// parameter, which is omitted so that the server default applies, from an explicit zero value such as
+// temperature 0 or store false. The zero Opt is unset.
+type Opt[T any] struct {
+	value T
+	set   bool
+}
+
+// Some returns an Opt set to value
+func Some[T any](value T) Opt[T] {
+	return Opt[T]{value: value, set: true}
+}
+
+// Float returns an Opt set to the given float
+func Float(value float64) Opt[float64] {
+	return Some(value)
+}
+
+// Int returns an Opt set to the given int
+func Int(value int) Opt[int] {
+	return Some(value)
+}
+
+// Bool returns an Opt set to the given bool
+func Bool(value bool) Opt[bool] {
+	return Some(value)
+}
+
+// Get returns the value and whether it is set
+func (o Opt[T]) Get() (T, bool) {
+	return o.value, o.set
+}
+
+// Value returns the value, or the zero value if unset
+func (o Opt[T]) Value() T {
+	return o.value
+}
+
+// Or returns the value, or fallback if unset
+func (o Opt[T]) Or(fallback T) T {
+	if !o.set {
+		return fallback
+	}
+	return o.value
+}
+
+// IsSet reports whether the value is set
+func (o Opt[T]) IsSet() bool {
+	return o.set
+}
+
+// IsZero reports whether the value is unset, so that fields tagged omitzero are omitted when unset
+func (o Opt[T]) IsZero() bool {
+	return !o.set
+}
+
+// MarshalJSON encodes the value, or null if unset
+func (o Opt[T]) MarshalJSON() ([]byte, error) {
+	if !o.set {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.value)
+}
+
+// UnmarshalJSON decodes the value, leaving the Opt unset for null
+func (o *Opt[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*o = Opt[T]{}
+		return nil
+	}
+
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*o = Some(value)
+	return nil
+}
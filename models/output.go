@@ -0,0 +1,505 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Output item types of the Responses API
+const (
+	OutputItemMessage             = "message"
+	OutputItemFunctionCall        = "function_call"
+	OutputItemReasoning           = "reasoning"
+	OutputItemFileSearchCall      = "file_search_call"
+	OutputItemWebSearchCall       = "web_search_call"
+	OutputItemComputerCall        = "computer_call"
+	OutputItemImageGenerationCall = "image_generation_call"
+	OutputItemCodeInterpreterCall = "code_interpreter_call"
+	OutputItemLocalShellCall      = "local_shell_call"
+	OutputItemMCPCall             = "mcp_call"
+	OutputItemMCPListTools        = "mcp_list_tools"
+	OutputItemMCPApprovalRequest  = "mcp_approval_request"
+	OutputItemCustomToolCall      = "custom_tool_call"
+)
+
+// Content part types of output messages
+const (
+	OutputContentText    = "output_text"
+	OutputContentRefusal = "refusal"
+)
+
+// OutputItem is an item of the output array of a response. Decoded items are pointers to one of the
+// item types of this package, such as *OutputMessage or *FunctionCallItem, or *UnknownOutputItem for
+// item types this package does not know.
+type OutputItem interface {
+	// OutputItemType returns the type of the item, e.g. "message"
+	OutputItemType() string
+}
+
+// OutputMessage is a message generated by the model
+type OutputMessage struct {
+	ID      string          `json:"id,omitempty"`
+	Role    string          `json:"role"`
+	Status  string          `json:"status,omitempty"`
+	Content []OutputContent `json:"content"`
+}
+
+// OutputContent is a content part of an output message, either output text or a refusal
+type OutputContent struct {
+	Type        string       `json:"type"`
+	Text        string       `json:"text,omitempty"`
+	Annotations []Annotation `json:"annotations,omitempty"`
+	Refusal     string       `json:"refusal,omitempty"`
+}
+
+// Annotation is a citation attached to output text
+type Annotation struct {
+	Type       string `json:"type"`
+	Index      int    `json:"index,omitempty"`
+	FileID     string `json:"file_id,omitempty"`
+	Filename   string `json:"filename,omitempty"`
+	URL        string `json:"url,omitempty"`
+	Title      string `json:"title,omitempty"`
+	StartIndex int    `json:"start_index,omitempty"`
+	EndIndex   int    `json:"end_index,omitempty"`
+}
+
+// FunctionCallItem is a call of a function tool by the model
+type FunctionCallItem struct {
+	ID        string `json:"id,omitempty"`
+	CallID    string `json:"call_id"`
+	Name      string `json:"name"`
+	Arguments string `json:"arguments"`
+	Status    string `json:"status,omitempty"`
+}
+
+// ReasoningItem holds the reasoning of a reasoning model
+type ReasoningItem struct {
+	ID               string             `json:"id,omitempty"`
+	Summary          []ReasoningSummary `json:"summary"`
+	EncryptedContent string             `json:"encrypted_content,omitempty"`
+	Status           string             `json:"status,omitempty"`
+}
+
+// ReasoningSummary is a part of the summary of a reasoning item
+type ReasoningSummary struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// FileSearchCallItem is a call of the file search tool
+type FileSearchCallItem struct {
+	ID      string             `json:"id,omitempty"`
+	Status  string             `json:"status,omitempty"`
+	Queries []string           `json:"queries,omitempty"`
+	Results []FileSearchResult `json:"results,omitempty"`
+}
+
+// FileSearchResult is a result of a file search call
+type FileSearchResult struct {
+	FileID     string         `json:"file_id,omitempty"`
+	Filename   string         `json:"filename,omitempty"`
+	Score      float64        `json:"score,omitempty"`
+	Text       string         `json:"text,omitempty"`
+	Attributes map[string]any `json:"attributes,omitempty"`
+}
+
+// WebSearchCallItem is a call of the web search tool
+type WebSearchCallItem struct {
+	ID     string          `json:"id,omitempty"`
+	Status string          `json:"status,omitempty"`
+	Action json.RawMessage `json:"action,omitempty"`
+}
+
+// ComputerCallItem is a call of the computer use tool
+type ComputerCallItem struct {
+	ID                  string          `json:"id,omitempty"`
+	CallID              string          `json:"call_id"`
+	Status              string          `json:"status,omitempty"`
+	Action              json.RawMessage `json:"action,omitempty"`
+	PendingSafetyChecks []SafetyCheck   `json:"pending_safety_checks,omitempty"`
+}
+
+// SafetyCheck is a safety check raised for a computer call
+type SafetyCheck struct {
+	ID      string `json:"id"`
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// ImageGenerationCallItem is a call of the image generation tool
+type ImageGenerationCallItem struct {
+	ID     string `json:"id,omitempty"`
+	Status string `json:"status,omitempty"`
+	// Result is the base64 encoded generated image
+	Result string `json:"result,omitempty"`
+}
+
+// CodeInterpreterCallItem is a call of the code interpreter tool
+type CodeInterpreterCallItem struct {
+	ID          string          `json:"id,omitempty"`
+	Status      string          `json:"status,omitempty"`
+	Code        string          `json:"code,omitempty"`
+	ContainerID string          `json:"container_id,omitempty"`
+	Outputs     json.RawMessage `json:"outputs,omitempty"`
+}
+
+// LocalShellCallItem is a call of the local shell tool
+type LocalShellCallItem struct {
+	ID     string          `json:"id,omitempty"`
+	CallID string          `json:"call_id"`
+	Status string          `json:"status,omitempty"`
+	Action json.RawMessage `json:"action,omitempty"`
+}
+
+// MCPCallItem is a call of a tool on a remote MCP server
+type MCPCallItem struct {
+	ID          string `json:"id,omitempty"`
+	ServerLabel string `json:"server_label"`
+	Name        string `json:"name"`
+	Arguments   string `json:"arguments"`
+	Output      string `json:"output,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// MCPListToolsItem lists the tools available on a remote MCP server
+type MCPListToolsItem struct {
+	ID          string          `json:"id,omitempty"`
+	ServerLabel string          `json:"server_label"`
+	Tools       json.RawMessage `json:"tools,omitempty"`
+	Error       string          `json:"error,omitempty"`
+}
+
+// MCPApprovalRequestItem asks for approval of a call of a tool on a remote MCP server
+type MCPApprovalRequestItem struct {
+	ID          string `json:"id,omitempty"`
+	ServerLabel string `json:"server_label"`
+	Name        string `json:"name"`
+	Arguments   string `json:"arguments"`
+}
+
+// CustomToolCallItem is a call of a custom tool by the model
+type CustomToolCallItem struct {
+	ID     string `json:"id,omitempty"`
+	CallID string `json:"call_id"`
+	Name   string `json:"name"`
+	Input  string `json:"input"`
+}
+
+// UnknownOutputItem is an output item of a type this package does not know. It keeps the raw JSON
+// of the item so that it survives a round trip, and can be sent back as an input item.
+type UnknownOutputItem struct {
+	Type string
+	Raw  json.RawMessage
+}
+
+// OutputItemType returns the type of the item
+func (OutputMessage) OutputItemType() string { return OutputItemMessage }
+
+// OutputItemType returns the type of the item
+func (FunctionCallItem) OutputItemType() string { return OutputItemFunctionCall }
+
+// OutputItemType returns the type of the item
+func (ReasoningItem) OutputItemType() string { return OutputItemReasoning }
+
+// OutputItemType returns the type of the item
+func (FileSearchCallItem) OutputItemType() string { return OutputItemFileSearchCall }
+
+// OutputItemType returns the type of the item
+func (WebSearchCallItem) OutputItemType() string { return OutputItemWebSearchCall }
+
+// OutputItemType returns the type of the item
+func (ComputerCallItem) OutputItemType() string { return OutputItemComputerCall }
+
+// OutputItemType returns the type of the item
+func (ImageGenerationCallItem) OutputItemType() string { return OutputItemImageGenerationCall }
+
+// OutputItemType returns the type of the item
+func (CodeInterpreterCallItem) OutputItemType() string { return OutputItemCodeInterpreterCall }
+
+// OutputItemType returns the type of the item
+func (LocalShellCallItem) OutputItemType() string { return OutputItemLocalShellCall }
+
+// OutputItemType returns the type of the item
+func (MCPCallItem) OutputItemType() string { return OutputItemMCPCall }
+
+// OutputItemType returns the type of the item
+func (MCPListToolsItem) OutputItemType() string { return OutputItemMCPListTools }
+
+// OutputItemType returns the type of the item
+func (MCPApprovalRequestItem) OutputItemType() string { return OutputItemMCPApprovalRequest }
+
+// OutputItemType returns the type of the item
+func (CustomToolCallItem) OutputItemType() string { return OutputItemCustomToolCall }
+
+// OutputItemType returns the type of the item
+func (i UnknownOutputItem) OutputItemType() string { return i.Type }
+
+// MarshalJSON encodes the item with its type
+func (i OutputMessage) MarshalJSON() ([]byte, error) {
+	type alias OutputMessage
+	return marshalWithType(i.OutputItemType(), alias(i))
+}
+
+// MarshalJSON encodes the item with its type
+func (i FunctionCallItem) MarshalJSON() ([]byte, error) {
+	type alias FunctionCallItem
+	return marshalWithType(i.OutputItemType(), alias(i))
+}
+
+// MarshalJSON encodes the item with its type
+func (i ReasoningItem) MarshalJSON() ([]byte, error) {
+	type alias ReasoningItem
+	if i.Summary == nil {
+		// The API requires the summary, even if empty
+		i.Summary = []ReasoningSummary{}
+	}
+	return marshalWithType(i.OutputItemType(), alias(i))
+}
+
+// MarshalJSON encodes the item with its type
+func (i FileSearchCallItem) MarshalJSON() ([]byte, error) {
+	type alias FileSearchCallItem
+	return marshalWithType(i.OutputItemType(), alias(i))
+}
+
+// MarshalJSON encodes the item with its type
+func (i WebSearchCallItem) MarshalJSON() ([]byte, error) {
+	type alias WebSearchCallItem
+	return marshalWithType(i.OutputItemType(), alias(i))
+}
+
+// MarshalJSON encodes the item with its type
+func (i ComputerCallItem) MarshalJSON() ([]byte, error) {
+	type alias ComputerCallItem
+	return marshalWithType(i.OutputItemType(), alias(i))
+}
+
+// MarshalJSON encodes the item with its type
+func (i ImageGenerationCallItem) MarshalJSON() ([]byte, error) {
+	type alias ImageGenerationCallItem
+	return marshalWithType(i.OutputItemType(), alias(i))
+}
+
+// MarshalJSON encodes the item with its type
+func (i CodeInterpreterCallItem) MarshalJSON() ([]byte, error) {
+	type alias CodeInterpreterCallItem
+	return marshalWithType(i.OutputItemType(), alias(i))
+}
+
+// MarshalJSON encodes the item with its type
+func (i LocalShellCallItem) MarshalJSON() ([]byte, error) {
+	type alias LocalShellCallItem
+	return marshalWithType(i.OutputItemType(), alias(i))
+}
+
+// MarshalJSON encodes the item with its type
+func (i MCPCallItem) MarshalJSON() ([]byte, error) {
+	type alias MCPCallItem
+	return marshalWithType(i.OutputItemType(), alias(i))
+}
+
+// MarshalJSON encodes the item with its type
+func (i MCPListToolsItem) MarshalJSON() ([]byte, error) {
+	type alias MCPListToolsItem
+	return marshalWithType(i.OutputItemType(), alias(i))
+}
+
+// MarshalJSON encodes the item with its type
+func (i MCPApprovalRequestItem) MarshalJSON() ([]byte, error) {
+	type alias MCPApprovalRequestItem
+	return marshalWithType(i.OutputItemType(), alias(i))
+}
+
+// MarshalJSON encodes the item with its type
+func (i CustomToolCallItem) MarshalJSON() ([]byte, error) {
+	type alias CustomToolCallItem
+	return marshalWithType(i.OutputItemType(), alias(i))
+}
+
+// MarshalJSON encodes the raw item
+func (i UnknownOutputItem) MarshalJSON() ([]byte, error) {
+	if len(i.Raw) == 0 {
+		return marshalWithType(i.Type, struct{}{})
+	}
+	return i.Raw, nil
+}
+
+// marshalWithType encodes v, which must encode as a JSON object, with a leading type field
+func marshalWithType(itemType string, v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	typeField, err := json.Marshal(itemType)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	b.WriteString(`{"type":`)
+	b.Write(typeField)
+	if len(data) > 2 {
+		b.WriteByte(',')
+		b.Write(data[1:])
+	} else {
+		b.WriteByte('}')
+	}
+	return b.Bytes(), nil
+}
+
+// UnmarshalOutputItem decodes a single output item into the item type matching its type field
+func UnmarshalOutputItem(data []byte) (OutputItem, error) {
+	var header struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, err
+	}
+
+	var item OutputItem
+	switch header.Type {
+	case OutputItemMessage:
+		item = &OutputMessage{}
+	case OutputItemFunctionCall:
+		item = &FunctionCallItem{}
+	case OutputItemReasoning:
+		item = &ReasoningItem{}
+	case OutputItemFileSearchCall:
+		item = &FileSearchCallItem{}
+	case OutputItemWebSearchCall:
+		item = &WebSearchCallItem{}
+	case OutputItemComputerCall:
+		item = &ComputerCallItem{}
+	case OutputItemImageGenerationCall:
+		item = &ImageGenerationCallItem{}
+	case OutputItemCodeInterpreterCall:
+		item = &CodeInterpreterCallItem{}
+	case OutputItemLocalShellCall:
+		item = &LocalShellCallItem{}
+	case OutputItemMCPCall:
+		item = &MCPCallItem{}
+	case OutputItemMCPListTools:
+		item = &MCPListToolsItem{}
+	case OutputItemMCPApprovalRequest:
+		item = &MCPApprovalRequestItem{}
+	case OutputItemCustomToolCall:
+		item = &CustomToolCallItem{}
+	default:
+		return &UnknownOutputItem{Type: header.Type, Raw: append(json.RawMessage{}, data...)}, nil
+	}
+
+	// The item types have no UnmarshalJSON, so the default decoding applies and ignores the type field
+	if err := json.Unmarshal(data, item); err != nil {
+		return nil, fmt.Errorf("decoding %s output item: %w", header.Type, err)
+	}
+	return item, nil
+}
+
+// OutputItems is the output array of a response
+type OutputItems []OutputItem
+
+// UnmarshalJSON decodes every item into the item type matching its type field
+func (items *OutputItems) UnmarshalJSON(data []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	decoded := make(OutputItems, 0, len(raw))
+	for _, itemData := range raw {
+		item, err := UnmarshalOutputItem(itemData)
+		if err != nil {
+			return err
+		}
+		decoded = append(decoded, item)
+	}
+	*items = decoded
+	return nil
+}
+
+// OutputText returns the text of all output_text parts of all messages, concatenated
+func (items OutputItems) OutputText() string {
+	var b strings.Builder
+	for _, item := range items {
+		if message, ok := outputMessage(item); ok {
+			for _, content := range message.Content {
+				if content.Type == OutputContentText {
+					b.WriteString(content.Text)
+				}
+			}
+		}
+	}
+	return b.String()
+}
+
+// Refusal returns the refusals of all messages, concatenated
+func (items OutputItems) Refusal() string {
+	var b strings.Builder
+	for _, item := range items {
+		if message, ok := outputMessage(item); ok {
+			for _, content := range message.Content {
+				if content.Type == OutputContentRefusal {
+					b.WriteString(content.Refusal)
+				}
+			}
+		}
+	}
+	return b.String()
+}
+
+// FunctionCalls returns the function calls in the output
+func (items OutputItems) FunctionCalls() []FunctionCallItem {
+	var calls []FunctionCallItem
+	for _, item := range items {
+		switch call := item.(type) {
+		case FunctionCallItem:
+			calls = append(calls, call)
+		case *FunctionCallItem:
+			if call != nil {
+				calls = append(calls, *call)
+			}
+		}
+	}
+	return calls
+}
+
+// outputMessage returns the message held by item, whether it was built as a value or a pointer
+func outputMessage(item OutputItem) (OutputMessage, bool) {
+	switch message := item.(type) {
+	case OutputMessage:
+		return message, true
+	case *OutputMessage:
+		if message != nil {
+			return *message, true
+		}
+	}
+	return OutputMessage{}, false
+}
+
+// Choices derives a single Chat Completions style choice from the output, holding the output text
+// and the function calls
+func (items OutputItems) Choices(status string) []ResponseChoice {
+	choice := ResponseChoice{
+		Message: ResponseMessage{
+			Role:    "assistant",
+			Content: items.OutputText(),
+		},
+	}
+
+	for _, call := range items.FunctionCalls() {
+		toolCall := ResponseToolCall{
+			ID:     call.ID,
+			CallID: call.CallID,
+			Type:   "function",
+		}
+		toolCall.Function.Name = call.Name
+		toolCall.Function.Arguments = call.Arguments
+		choice.ToolCalls = append(choice.ToolCalls, toolCall)
+	}
+
+	choice.FinishReason = FinishReason(status, len(choice.ToolCalls) > 0)
+	return []ResponseChoice{choice}
+}
@@ -0,0 +1,26 @@
+package models
+
+import "testing"
+
+func TestOutputItemsValuesAndPointers(t *testing.T) {
+	message := OutputMessage{Role: "assistant", Content: []OutputContent{
+		{Type: OutputContentText, Text: "hello"},
+		{Type: OutputContentRefusal, Refusal: "no"},
+	}}
+	call := FunctionCallItem{CallID: "call_1", Name: "get_weather"}
+
+	for name, items := range map[string]OutputItems{
+		"values":   {message, call},
+		"pointers": {&message, &call},
+	} {
+		if got := items.OutputText(); got != "hello" {
+			t.Errorf("%s: got output text %q, want hello", name, got)
+		}
+		if got := items.Refusal(); got != "no" {
+			t.Errorf("%s: got refusal %q, want no", name, got)
+		}
+		if calls := items.FunctionCalls(); len(calls) != 1 || calls[0].Name != "get_weather" {
+			t.Errorf("%s: got function calls %+v", name, calls)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// RateLimitInfo represents the rate limit state reported by the API in the response headers
+type RateLimitInfo struct {
+	// LimitRequests is the maximum number of requests permitted before exhausting the rate limit
+	LimitRequests int `json:"limit_requests,omitempty"`
+	// LimitTokens is the maximum number of tokens permitted before exhausting the rate limit
+	LimitTokens int `json:"limit_tokens,omitempty"`
+	// RemainingRequests is the number of requests remaining before exhausting the rate limit
+	RemainingRequests int `json:"remaining_requests,omitempty"`
+	// RemainingTokens is the number of tokens remaining before exhausting the rate limit
+	RemainingTokens int `json:"remaining_tokens,omitempty"`
+	// ResetRequests is the time until the request rate limit resets to its initial state
+	ResetRequests time.Duration `json:"reset_requests,omitempty"`
+	// ResetTokens is the time until the token rate limit resets to its initial state
+	ResetTokens time.Duration `json:"reset_tokens,omitempty"`
+}
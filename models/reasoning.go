@@ -0,0 +1,95 @@
+package models
+
+import (
+	"slices"
+	"strings"
+)
+
+// Reasoning efforts of reasoning models
+const (
+	ReasoningEffortMinimal = "minimal"
+	ReasoningEffortLow     = "low"
+	ReasoningEffortMedium  = "medium"
+	ReasoningEffortHigh    = "high"
+)
+
+// Reasoning summary levels of reasoning models
+const (
+	ReasoningSummaryAuto     = "auto"
+	ReasoningSummaryConcise  = "concise"
+	ReasoningSummaryDetailed = "detailed"
+)
+
+// IncludeReasoningEncryptedContent includes the encrypted content of reasoning items in the output,
+// so that the reasoning can be sent back when responses are not stored
+const IncludeReasoningEncryptedContent = "reasoning.encrypted_content"
+
+// Reasoning configures reasoning models
+type Reasoning struct {
+	// Effort is the reasoning effort, e.g. ReasoningEffortLow
+	Effort string `json:"effort,omitempty"`
+	// Summary requests a summary of the reasoning, e.g. ReasoningSummaryAuto
+	Summary string `json:"summary,omitempty"`
+}
+
+// SummaryText returns the text of the summary parts, separated by blank lines
+func (i ReasoningItem) SummaryText() string {
+	texts := make([]string, 0, len(i.Summary))
+	for _, summary := range i.Summary {
+		texts = append(texts, summary.Text)
+	}
+	return strings.Join(texts, "\n\n")
+}
+
+// ReasoningItems returns the reasoning items in the output
+func (items OutputItems) ReasoningItems() []ReasoningItem {
+	var reasoning []ReasoningItem
+	for _, item := range items {
+		switch r := item.(type) {
+		case ReasoningItem:
+			reasoning = append(reasoning, r)
+		case *ReasoningItem:
+			if r != nil {
+				reasoning = append(reasoning, *r)
+			}
+		}
+	}
+	return reasoning
+}
+
+// Stateless returns a copy of the request that is not stored and includes the encrypted content of
+// reasoning items, so that the reasoning can be carried forward with FollowUp
+func (r ResponseRequest) Stateless() ResponseRequest {
+	r.Store = Bool(false)
+	if !slices.Contains(r.Include, IncludeReasoningEncryptedContent) {
+		r.Include = append(slices.Clip(r.Include), IncludeReasoningEncryptedContent)
+	}
+	return r
+}
+
+// FollowUp returns the request for the next turn of a conversation without stored responses. Its input
+// is the input of r, followed by the output of response and the given input. Reasoning items carry their
+// encrypted content forward. If r is not stored, reasoning items without encrypted content are left out,
+// since the server could not resolve them.
+func (r ResponseRequest) FollowUp(response *ResponseResponse, input ...InputItem) (ResponseRequest, error) {
+	output, err := response.Output.AsInput()
+	if err != nil {
+		return r, err
+	}
+
+	if store, ok := r.Store.Get(); ok && !store {
+		output = slices.DeleteFunc(output, func(item InputItem) bool {
+			switch reasoning := item.(type) {
+			case ReasoningItem:
+				return reasoning.EncryptedContent == ""
+			case *ReasoningItem:
+				return reasoning != nil && reasoning.EncryptedContent == ""
+			}
+			return false
+		})
+	}
+
+	r.Input = slices.Concat(r.Input, output, InputItems(input))
+	r.PreviousResponseID = ""
+	return r, nil
+}
@@ -0,0 +1,26 @@
+package models
+
+import "testing"
+
+func TestFollowUpReasoningValues(t *testing.T) {
+	request := ResponseRequest{Model: "m"}.Stateless()
+	response := &ResponseResponse{Output: OutputItems{
+		ReasoningItem{ID: "rs_1"},
+		ReasoningItem{ID: "rs_2", EncryptedContent: "opaque"},
+	}}
+
+	if got := len(response.Output.ReasoningItems()); got != 2 {
+		t.Errorf("got %d reasoning items, want 2", got)
+	}
+
+	next, err := request.FollowUp(response)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(next.Input) != 1 {
+		t.Fatalf("got input %+v, want only the reasoning item with encrypted content", next.Input)
+	}
+	if reasoning, ok := next.Input[0].(ReasoningItem); !ok || reasoning.ID != "rs_2" {
+		t.Errorf("got input item %+v, want rs_2", next.Input[0])
+	}
+}
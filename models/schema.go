@@ -0,0 +1,338 @@
+package models
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Text format types of the Responses API
+const (
+	TextFormatText       = "text"
+	TextFormatJSONObject = "json_object"
+	TextFormatJSONSchema = "json_schema"
+)
+
+// TextConfig configures the text output of the model
+type TextConfig struct {
+	// Format is the format of the text output, plain text by default
+	Format *TextFormat `json:"format,omitempty"`
+}
+
+// TextFormat is the format of the text output of the model
+type TextFormat struct {
+	// Type is TextFormatText, TextFormatJSONObject or TextFormatJSONSchema
+	Type string `json:"type"`
+	// Name is the name of the JSON schema
+	Name string `json:"name,omitempty"`
+	// Description describes the JSON schema to the model
+	Description string `json:"description,omitempty"`
+	// Schema is the JSON schema of the output
+	Schema any `json:"schema,omitempty"`
+	// Strict enables strict adherence to the JSON schema
+	Strict Opt[bool] `json:"strict,omitzero"`
+}
+
+// ParsedResponse is a response whose structured output was decoded into a T
+type ParsedResponse[T any] struct {
+	*ResponseResponse
+	// Parsed is the decoded output
+	Parsed T
+}
+
+// JSONSchemaFormat creates a new strict JSON schema text format
+func JSONSchemaFormat(name string, schema any) *TextFormat {
+	return &TextFormat{
+		Type:   TextFormatJSONSchema,
+		Name:   name,
+		Schema: schema,
+		Strict: Bool(true),
+	}
+}
+
+// JSONSchemaFor derives a strict JSON schema for structured outputs from the Go type T, which must be
+// a struct. Fields are named by their json tags, and fields tagged "-" and unexported fields are
+// skipped. A description tag describes a field to the model, and an enum tag lists the allowed values
+// separated by commas. Strict schemas require every field, so pointer fields and fields tagged
+// omitempty are required but nullable. Nested structs and slices are supported, while maps, interfaces
+// and recursive types are not.
+func JSONSchemaFor[T any]() (map[string]any, error) {
+	t := reflect.TypeFor[T]()
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("openai: JSON schema root must be a struct, not %s", t)
+	}
+	return schemaFor(t, map[reflect.Type]bool{})
+}
+
+// SchemaName returns a JSON schema name for the Go type T, derived from its type name
+func SchemaName[T any]() string {
+	t := reflect.TypeFor[T]()
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	name := invalidSchemaNameChars.ReplaceAllString(t.Name(), "_")
+	if name == "" || name == "_" {
+		return "output"
+	}
+	return name
+}
+
+// invalidSchemaNameChars matches the characters not allowed in JSON schema names
+var invalidSchemaNameChars = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+var (
+	timeType          = reflect.TypeFor[time.Time]()
+	rawMessageType    = reflect.TypeFor[json.RawMessage]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// schemaFor derives the schema of t. visiting holds the struct types being derived to detect recursion.
+func schemaFor(t reflect.Type, visiting map[reflect.Type]bool) (map[string]any, error) {
+	switch {
+	case t == timeType:
+		return map[string]any{"type": "string", "format": "date-time"}, nil
+	case t == rawMessageType:
+		return nil, fmt.Errorf("openai: JSON schema does not support %s", t)
+	case t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType):
+		return map[string]any{"type": "string"}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}, nil
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}, nil
+	case reflect.Pointer:
+		return schemaFor(t.Elem(), visiting)
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// Byte slices are encoded as base64 strings
+			return map[string]any{"type": "string"}, nil
+		}
+		items, err := schemaFor(t.Elem(), visiting)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "array", "items": items}, nil
+	case reflect.Struct:
+		return structSchema(t, visiting)
+	}
+	return nil, fmt.Errorf("openai: JSON schema does not support %s", t)
+}
+
+// structSchema derives the schema of the struct type t
+func structSchema(t reflect.Type, visiting map[reflect.Type]bool) (map[string]any, error) {
+	if visiting[t] {
+		return nil, fmt.Errorf("openai: JSON schema does not support recursive type %s", t)
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	properties := map[string]any{}
+	required := []string{}
+	if err := addFields(t, visiting, properties, &required); err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"required":             required,
+		"additionalProperties": false,
+	}, nil
+}
+
+// addFields adds the properties of the fields of the struct type t, including the promoted fields
+// of embedded structs. Conflicting names are resolved like encoding/json does: a shallower field
+// shadows deeper ones, and of several fields at the same depth only a single tagged one is kept.
+func addFields(t reflect.Type, visiting map[reflect.Type]bool, properties map[string]any, required *[]string) error {
+	fields, err := collectFields(t, visiting, 0, nil)
+	if err != nil {
+		return err
+	}
+
+	byName := map[string][]schemaField{}
+	for _, f := range fields {
+		byName[f.name] = append(byName[f.name], f)
+	}
+	for _, f := range fields {
+		if dominant, ok := dominantField(byName[f.name]); !ok || dominant.index != f.index {
+			continue
+		}
+		schema, err := fieldSchema(f, visiting)
+		if err != nil {
+			return err
+		}
+		properties[f.name] = schema
+		*required = append(*required, f.name)
+	}
+	return nil
+}
+
+// schemaField is a field of a struct or of its embedded structs that becomes a property
+type schemaField struct {
+	reflect.StructField
+	owner  reflect.Type
+	name   string
+	opts   string
+	tagged bool
+	depth  int
+	index  int
+}
+
+// collectFields returns the fields of the struct type t that become properties in declaration order,
+// descending into embedded structs
+func collectFields(t reflect.Type, visiting map[reflect.Type]bool, depth int, fields []schemaField) ([]schemaField, error) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				if visiting[embedded] {
+					return nil, fmt.Errorf("openai: JSON schema does not support recursive type %s", embedded)
+				}
+				visiting[embedded] = true
+				var err error
+				fields, err = collectFields(embedded, visiting, depth+1, fields)
+				delete(visiting, embedded)
+				if err != nil {
+					return nil, err
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		tagged := name != ""
+		if !tagged {
+			name = field.Name
+		}
+		fields = append(fields, schemaField{
+			StructField: field,
+			owner:       t,
+			name:        name,
+			opts:        opts,
+			tagged:      tagged,
+			depth:       depth,
+			index:       len(fields),
+		})
+	}
+	return fields, nil
+}
+
+// dominantField returns the field that a JSON name refers to among the fields with that name, or
+// false if they conflict
+func dominantField(fields []schemaField) (schemaField, bool) {
+	var dominant []schemaField
+	for _, f := range fields {
+		switch {
+		case len(dominant) == 0 || f.depth < dominant[0].depth:
+			dominant = []schemaField{f}
+		case f.depth == dominant[0].depth:
+			dominant = append(dominant, f)
+		}
+	}
+	if len(dominant) == 1 {
+		return dominant[0], true
+	}
+
+	var tagged []schemaField
+	for _, f := range dominant {
+		if f.tagged {
+			tagged = append(tagged, f)
+		}
+	}
+	if len(tagged) == 1 {
+		return tagged[0], true
+	}
+	return schemaField{}, false
+}
+
+// fieldSchema derives the schema of the property of a field
+func fieldSchema(f schemaField, visiting map[reflect.Type]bool) (map[string]any, error) {
+	schema, err := schemaFor(f.Type, visiting)
+	if err != nil {
+		return nil, fmt.Errorf("%w (field %s.%s)", err, f.owner.Name(), f.Name)
+	}
+	if description := f.Tag.Get("description"); description != "" {
+		schema["description"] = description
+	}
+	if enum := f.Tag.Get("enum"); enum != "" {
+		values, err := enumValues(enum, schema["type"])
+		if err != nil {
+			return nil, fmt.Errorf("openai: invalid enum tag of field %s.%s: %w", f.owner.Name(), f.Name, err)
+		}
+		schema["enum"] = values
+	}
+
+	// Strict schemas require every property, so optional fields are expressed as nullable
+	if f.Type.Kind() == reflect.Pointer || hasOption(f.opts, "omitempty") || hasOption(f.opts, "omitzero") {
+		schema["type"] = []any{schema["type"], "null"}
+		if values, ok := schema["enum"].([]any); ok {
+			schema["enum"] = append(values, nil)
+		}
+	}
+	return schema, nil
+}
+
+// enumValues parses the comma separated values of an enum tag for a property of the given type
+func enumValues(enum string, schemaType any) ([]any, error) {
+	var values []any
+	for _, value := range strings.Split(enum, ",") {
+		value = strings.TrimSpace(value)
+		switch schemaType {
+		case "string":
+			values = append(values, value)
+		case "integer":
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, n)
+		case "number":
+			f, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, f)
+		default:
+			return nil, fmt.Errorf("enum is not supported for %v properties", schemaType)
+		}
+	}
+	return values, nil
+}
+
+// hasOption reports whether the options of a json tag contain option
+func hasOption(opts, option string) bool {
+	for opts != "" {
+		var current string
+		current, opts, _ = strings.Cut(opts, ",")
+		if current == option {
+			return true
+		}
+	}
+	return false
+}
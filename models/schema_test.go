@@ -0,0 +1,101 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+type embeddedNode struct {
+	*embeddedNode
+	X int `json:"x"`
+}
+
+type fieldNode struct {
+	Next *fieldNode `json:"next"`
+}
+
+type embeddedBase struct {
+	ID string `json:"id"`
+}
+
+type embeddingStruct struct {
+	embeddedBase
+	Name string `json:"name,omitempty"`
+}
+
+type shadowedBase struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type shadowingStruct struct {
+	shadowedBase
+	Name int `json:"name"`
+}
+
+type conflictA struct {
+	Value string
+}
+
+type conflictB struct {
+	Value int
+}
+
+type conflictingStruct struct {
+	conflictA
+	conflictB
+	Other string `json:"other"`
+}
+
+func TestJSONSchemaForRecursiveTypes(t *testing.T) {
+	if _, err := JSONSchemaFor[embeddedNode](); err == nil || !strings.Contains(err.Error(), "recursive type") {
+		t.Errorf("embedded recursion: got error %v, want recursive type error", err)
+	}
+	if _, err := JSONSchemaFor[fieldNode](); err == nil || !strings.Contains(err.Error(), "recursive type") {
+		t.Errorf("field recursion: got error %v, want recursive type error", err)
+	}
+}
+
+func TestJSONSchemaForEmbeddedStruct(t *testing.T) {
+	schema, err := JSONSchemaFor[embeddingStruct]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	properties := schema["properties"].(map[string]any)
+	if _, ok := properties["id"]; !ok {
+		t.Errorf("embedded field id not promoted: %v", properties)
+	}
+	name := properties["name"].(map[string]any)
+	if types, ok := name["type"].([]any); !ok || len(types) != 2 || types[1] != "null" {
+		t.Errorf("omitempty field name not nullable: %v", name)
+	}
+	if required := schema["required"].([]string); len(required) != 2 {
+		t.Errorf("got required %v, want id and name", required)
+	}
+}
+
+func TestJSONSchemaForShadowedFields(t *testing.T) {
+	schema, err := JSONSchemaFor[shadowingStruct]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	properties := schema["properties"].(map[string]any)
+	if got := properties["name"].(map[string]any)["type"]; got != "integer" {
+		t.Errorf("got name type %v, want the outer integer field", got)
+	}
+	if required := schema["required"].([]string); len(required) != 2 {
+		t.Errorf("got required %v, want id and name once", required)
+	}
+
+	schema, err = JSONSchemaFor[conflictingStruct]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	properties = schema["properties"].(map[string]any)
+	if _, ok := properties["Value"]; ok {
+		t.Errorf("conflicting fields at the same depth not dropped: %v", properties)
+	}
+	if required := schema["required"].([]string); len(required) != 1 || required[0] != "other" {
+		t.Errorf("got required %v, want other", required)
+	}
+}
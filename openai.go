@@ -1,16 +1,24 @@
 package openairesponses
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"log/slog"
 	"net/http"
+	"net/url"
+	"time"
 
 	"github.com/gosticks/openai-responses-api-go/client"
 	"github.com/gosticks/openai-responses-api-go/models"
 )
 
-// Client is the client for the OpenAI Responses API
+// Client is the client for the OpenAI Responses API. It is safe for concurrent use.
 type Client struct {
 	// Responses is the client for the Responses API
 	Responses *client.Responses
+
+	client *client.Client
 }
 
 // NewClient creates a new OpenAI Responses API client
@@ -25,19 +33,72 @@ func NewClient(apiKey string, options ...client.ClientOption) *Client {
 
 	return &Client{
 		Responses: responsesClient,
+		client:    baseClient,
+	}
+}
+
+// With returns a new client that shares the configuration and the HTTP transport of c,
+// with the given options applied on top
+func (c *Client) With(options ...client.ClientOption) *Client {
+	baseClient := c.client.With(options...)
+	return &Client{
+		Responses: client.NewResponses(baseClient),
+		client:    baseClient,
 	}
 }
 
+// Shutdown stops the client from accepting new calls and waits for the calls and streams in flight,
+// canceling them when ctx ends
+func (c *Client) Shutdown(ctx context.Context) error {
+	return c.client.Shutdown(ctx)
+}
+
+// EndpointHealth returns a snapshot of the health of the configured endpoints,
+// or nil if the client uses a single base URL
+func (c *Client) EndpointHealth() []EndpointHealth {
+	return c.client.EndpointHealth()
+}
+
 // WithBaseURL sets the base URL for the client
 func WithBaseURL(baseURL string) client.ClientOption {
 	return client.WithBaseURL(baseURL)
 }
 
+// WithAPIKey sets the API key for the client, e.g. for a client derived with With
+func WithAPIKey(apiKey string) client.ClientOption {
+	return client.WithAPIKey(apiKey)
+}
+
 // WithHTTPClient sets the HTTP client for the client
 func WithHTTPClient(httpClient *http.Client) client.ClientOption {
 	return client.WithHTTPClient(httpClient)
 }
 
+// WithProxy routes all requests through the given proxy
+func WithProxy(proxyURL *url.URL) client.ClientOption {
+	return client.WithProxy(proxyURL)
+}
+
+// WithRootCAs sets the certificate authorities used to verify the server
+func WithRootCAs(pool *x509.CertPool) client.ClientOption {
+	return client.WithRootCAs(pool)
+}
+
+// WithClientCertificates sets the certificates presented to the server for mutual TLS
+func WithClientCertificates(certificates ...tls.Certificate) client.ClientOption {
+	return client.WithClientCertificates(certificates...)
+}
+
+// WithRequestSigner sets a signer that is called with every outgoing request and its serialized body
+func WithRequestSigner(signer client.RequestSigner) client.ClientOption {
+	return client.WithRequestSigner(signer)
+}
+
+// WithHARRecorder records all HTTP traffic of the client with the given recorder
+func WithHARRecorder(recorder *client.HARRecorder) client.ClientOption {
+	return client.WithHARRecorder(recorder)
+}
+
 // WithUserAgent sets the user agent for the client
 func WithUserAgent(userAgent string) client.ClientOption {
 	return client.WithUserAgent(userAgent)
@@ -48,6 +109,167 @@ func WithOrganization(organization string) client.ClientOption {
 	return client.WithOrganization(organization)
 }
 
+// WithProject sets the project ID for the client
+func WithProject(project string) client.ClientOption {
+	return client.WithProject(project)
+}
+
+// WithTenantRegistry sets the registry consulted for calls that select a tenant
+func WithTenantRegistry(registry *client.TenantRegistry) client.ClientOption {
+	return client.WithTenantRegistry(registry)
+}
+
+// WithRetryPolicy sets the retry policy for the client
+func WithRetryPolicy(policy client.RetryPolicy) client.ClientOption {
+	return client.WithRetryPolicy(policy)
+}
+
+// WithRateLimiter sets a client-wide rate limiter that delays outgoing requests
+func WithRateLimiter(limiter *client.RateLimiter) client.ClientOption {
+	return client.WithRateLimiter(limiter)
+}
+
+// WithConcurrencyLimiter sets a limiter that bounds the number of concurrent calls of the client
+func WithConcurrencyLimiter(limiter *client.ConcurrencyLimiter) client.ClientOption {
+	return client.WithConcurrencyLimiter(limiter)
+}
+
+// WithMiddleware appends middleware to the client pipeline
+func WithMiddleware(middleware ...client.Middleware) client.ClientOption {
+	return client.WithMiddleware(middleware...)
+}
+
+// WithTimeout sets the total timeout for non-streaming requests
+func WithTimeout(timeout time.Duration) client.ClientOption {
+	return client.WithTimeout(timeout)
+}
+
+// WithStreamTimeouts sets the connect, first event and idle timeouts for streams
+func WithStreamTimeouts(timeouts client.StreamTimeouts) client.ClientOption {
+	return client.WithStreamTimeouts(timeouts)
+}
+
+// WithEndpoints configures several endpoints to fail over between
+func WithEndpoints(endpoints ...client.Endpoint) client.ClientOption {
+	return client.WithEndpoints(endpoints...)
+}
+
+// WithCircuitBreaker sets the circuit breaker configuration used with multiple endpoints
+func WithCircuitBreaker(config client.CircuitBreakerConfig) client.ClientOption {
+	return client.WithCircuitBreaker(config)
+}
+
+// WithLogger sets the structured logger for the client
+func WithLogger(logger *slog.Logger) client.ClientOption {
+	return client.WithLogger(logger)
+}
+
+// WithLogConfig sets the log configuration for the client
+func WithLogConfig(config client.LogConfig) client.ClientOption {
+	return client.WithLogConfig(config)
+}
+
+// WithTracer sets the tracer for the client
+func WithTracer(tracer client.Tracer) client.ClientOption {
+	return client.WithTracer(tracer)
+}
+
+// WithMeter sets the meter for the client
+func WithMeter(meter client.Meter) client.ClientOption {
+	return client.WithMeter(meter)
+}
+
+// WithCredentialProvider sets the credential provider consulted for every request
+func WithCredentialProvider(provider client.CredentialProvider) client.ClientOption {
+	return client.WithCredentialProvider(provider)
+}
+
+// WithAzure configures the client to target Azure OpenAI
+func WithAzure(endpoint, apiVersion string, options ...client.AzureOption) client.ClientOption {
+	return client.WithAzure(endpoint, apiVersion, options...)
+}
+
+// WithAzureDeployment routes Azure OpenAI requests to the given deployment
+func WithAzureDeployment(deployment string) client.AzureOption {
+	return client.WithAzureDeployment(deployment)
+}
+
+// WithAzureV1 routes Azure OpenAI requests to the v1 API
+func WithAzureV1() client.AzureOption {
+	return client.WithAzureV1()
+}
+
+// WithAzureTokenProvider authenticates Azure OpenAI requests with Microsoft Entra ID bearer tokens
+func WithAzureTokenProvider(provider func(ctx context.Context) (string, error)) client.AzureOption {
+	return client.WithAzureTokenProvider(provider)
+}
+
+// WithHeader sets an extra header on a single request
+func WithHeader(key, value string) client.RequestOption {
+	return client.WithHeader(key, value)
+}
+
+// WithQuery adds a query parameter to a single request
+func WithQuery(key, value string) client.RequestOption {
+	return client.WithQuery(key, value)
+}
+
+// WithRequestTimeout sets a timeout for a single request
+func WithRequestTimeout(timeout time.Duration) client.RequestOption {
+	return client.WithRequestTimeout(timeout)
+}
+
+// WithRequestAPIKey overrides the client API key for a single request
+func WithRequestAPIKey(apiKey string) client.RequestOption {
+	return client.WithRequestAPIKey(apiKey)
+}
+
+// WithRequestOrganization overrides the client organization ID for a single request
+func WithRequestOrganization(organization string) client.RequestOption {
+	return client.WithRequestOrganization(organization)
+}
+
+// WithRequestProject overrides the client project ID for a single request
+func WithRequestProject(project string) client.RequestOption {
+	return client.WithRequestProject(project)
+}
+
+// WithTenant selects the tenant for a single request
+func WithTenant(id string) client.RequestOption {
+	return client.WithTenant(id)
+}
+
+// WithIdempotencyKey sets the idempotency key for a single request
+func WithIdempotencyKey(key string) client.RequestOption {
+	return client.WithIdempotencyKey(key)
+}
+
+// WithMaxRetries overrides the maximum number of retries for a single request
+func WithMaxRetries(maxRetries int) client.RequestOption {
+	return client.WithMaxRetries(maxRetries)
+}
+
+// WithPriority sets the priority of a single request in the concurrency limiter queue
+func WithPriority(priority client.Priority) client.RequestOption {
+	return client.WithPriority(priority)
+}
+
+// WithHedging enables hedged requests for a single non-streaming request
+func WithHedging(policy client.HedgePolicy) client.RequestOption {
+	return client.WithHedging(policy)
+}
+
+// Parse creates a response whose output follows a strict JSON schema derived from T, and decodes the
+// output into a T. If the model refuses, a RefusalError is returned.
+func Parse[T any](ctx context.Context, c *Client, request ResponseRequest, options ...client.RequestOption) (*ParsedResponse[T], error) {
+	return client.Parse[T](ctx, c.Responses, request, options...)
+}
+
+// Some returns an Opt set to value
+func Some[T any](value T) models.Opt[T] {
+	return models.Some(value)
+}
+
 // Export models
 type (
 	// ResponseMessage represents a message in a response
@@ -80,6 +302,160 @@ type (
 	ResponsesStreamAccumulator = client.ResponsesStreamAccumulator
 	// ResponseInputMessage represents a message in the input field
 	ResponseInputMessage = models.ResponseInputMessage
+	// Reasoning configures reasoning models
+	Reasoning = models.Reasoning
+	// TextConfig configures the text output of the model
+	TextConfig = models.TextConfig
+	// TextFormat is the format of the text output of the model
+	TextFormat = models.TextFormat
+	// ParsedResponse is a response whose structured output was decoded into a T
+	ParsedResponse[T any] = models.ParsedResponse[T]
+	// RefusalError is returned by Parse when the model refuses to produce the requested structured output
+	RefusalError = client.RefusalError
+	// Opt is an optional request parameter that distinguishes an unset parameter from an explicit zero value
+	Opt[T any] = models.Opt[T]
+	// InputItem is an item of the input array of a request
+	InputItem = models.InputItem
+	// InputItems is the input array of a request
+	InputItems = models.InputItems
+	// InputMessage is a message with content parts such as text, images and files
+	InputMessage = models.InputMessage
+	// InputContent is a content part of an input message
+	InputContent = models.InputContent
+	// FunctionCallOutputItem is the output of a function call, sent back to the model
+	FunctionCallOutputItem = models.FunctionCallOutputItem
+	// ItemReference refers to an item of a previous response by its ID
+	ItemReference = models.ItemReference
+	// OutputItem is an item of the output array of a response
+	OutputItem = models.OutputItem
+	// OutputItems is the output array of a response
+	OutputItems = models.OutputItems
+	// OutputMessage is a message generated by the model
+	OutputMessage = models.OutputMessage
+	// OutputContent is a content part of an output message
+	OutputContent = models.OutputContent
+	// Annotation is a citation attached to output text
+	Annotation = models.Annotation
+	// FunctionCallItem is a call of a function tool by the model
+	FunctionCallItem = models.FunctionCallItem
+	// ReasoningItem holds the reasoning of a reasoning model
+	ReasoningItem = models.ReasoningItem
+	// ReasoningSummary is a part of the summary of a reasoning item
+	ReasoningSummary = models.ReasoningSummary
+	// FileSearchCallItem is a call of the file search tool
+	FileSearchCallItem = models.FileSearchCallItem
+	// FileSearchResult is a result of a file search call
+	FileSearchResult = models.FileSearchResult
+	// WebSearchCallItem is a call of the web search tool
+	WebSearchCallItem = models.WebSearchCallItem
+	// ComputerCallItem is a call of the computer use tool
+	ComputerCallItem = models.ComputerCallItem
+	// SafetyCheck is a safety check raised for a computer call
+	SafetyCheck = models.SafetyCheck
+	// ImageGenerationCallItem is a call of the image generation tool
+	ImageGenerationCallItem = models.ImageGenerationCallItem
+	// CodeInterpreterCallItem is a call of the code interpreter tool
+	CodeInterpreterCallItem = models.CodeInterpreterCallItem
+	// LocalShellCallItem is a call of the local shell tool
+	LocalShellCallItem = models.LocalShellCallItem
+	// MCPCallItem is a call of a tool on a remote MCP server
+	MCPCallItem = models.MCPCallItem
+	// MCPListToolsItem lists the tools available on a remote MCP server
+	MCPListToolsItem = models.MCPListToolsItem
+	// MCPApprovalRequestItem asks for approval of a call of a tool on a remote MCP server
+	MCPApprovalRequestItem = models.MCPApprovalRequestItem
+	// CustomToolCallItem is a call of a custom tool by the model
+	CustomToolCallItem = models.CustomToolCallItem
+	// UnknownOutputItem is an output item of a type this package does not know
+	UnknownOutputItem = models.UnknownOutputItem
+	// RetryPolicy configures how failed requests are retried
+	RetryPolicy = client.RetryPolicy
+	// RateLimiter throttles outgoing requests on the client side
+	RateLimiter = client.RateLimiter
+	// ConcurrencyLimiter bounds the number of concurrent calls and queues the rest by priority
+	ConcurrencyLimiter = client.ConcurrencyLimiter
+	// ConcurrencyStats is a snapshot of the state of a concurrency limiter
+	ConcurrencyStats = client.ConcurrencyStats
+	// Priority orders calls waiting for a slot of the concurrency limiter
+	Priority = client.Priority
+	// RateLimitInfo represents the rate limit state reported by the API
+	RateLimitInfo = models.RateLimitInfo
+	// Middleware wraps a Handler to add cross-cutting behavior to every API call
+	Middleware = client.Middleware
+	// Handler sends a request through the client pipeline
+	Handler = client.Handler
+	// Request describes an outgoing API call as seen by middleware
+	Request = client.Request
+	// Response describes the result of an API call as seen by middleware
+	Response = client.Response
+	// ResponseMetadata represents the HTTP level details of an API response
+	ResponseMetadata = models.ResponseMetadata
+	// APIError represents an error returned by the OpenAI API
+	APIError = client.APIError
+	// AuthenticationError is returned when the API rejects the credentials
+	AuthenticationError = client.AuthenticationError
+	// PermissionDeniedError is returned when the credentials lack access to a resource
+	PermissionDeniedError = client.PermissionDeniedError
+	// NotFoundError is returned when the requested resource does not exist
+	NotFoundError = client.NotFoundError
+	// RateLimitError is returned when a rate limit or quota is exceeded
+	RateLimitError = client.RateLimitError
+	// ContextLengthExceededError is returned when the input exceeds the model context window
+	ContextLengthExceededError = client.ContextLengthExceededError
+	// ServerError is returned when the API or an intermediate proxy fails
+	ServerError = client.ServerError
+	// TimeoutError is returned when a request or stream times out
+	TimeoutError = client.TimeoutError
+	// StreamInterruptedError is returned when a stream ends before the response completed
+	StreamInterruptedError = client.StreamInterruptedError
+	// ClientClosedError is returned for calls made after or canceled by Shutdown
+	ClientClosedError = client.ClientClosedError
+	// AzureConfig configures the client to target an Azure OpenAI resource
+	AzureConfig = client.AzureConfig
+	// CredentialProvider supplies the API key or bearer token for requests
+	CredentialProvider = client.CredentialProvider
+	// CredentialReporter is implemented by credential providers that react to the outcome of requests
+	CredentialReporter = client.CredentialReporter
+	// FileCredential reads the key from a file and reloads it when the file changes
+	FileCredential = client.FileCredential
+	// RefreshingCredential caches a short-lived token and refreshes it before it expires
+	RefreshingCredential = client.RefreshingCredential
+	// CredentialPool rotates between several keys and benches rejected or rate limited keys
+	CredentialPool = client.CredentialPool
+	// TokenSource fetches a short-lived token and its expiry time
+	TokenSource = client.TokenSource
+	// StreamTimeouts configures the timeouts enforced while streaming a response
+	StreamTimeouts = client.StreamTimeouts
+	// Endpoint is an API endpoint the client can fail over between
+	Endpoint = client.Endpoint
+	// CircuitBreakerConfig configures when an endpoint is taken out of rotation
+	CircuitBreakerConfig = client.CircuitBreakerConfig
+	// EndpointHealth is a snapshot of the health of an endpoint
+	EndpointHealth = client.EndpointHealth
+	// LogConfig configures what the client logs
+	LogConfig = client.LogConfig
+	// Tracer starts spans for API calls
+	Tracer = client.Tracer
+	// Span is a traced unit of work
+	Span = client.Span
+	// Meter records metrics for API calls
+	Meter = client.Meter
+	// Attribute is a key-value pair attached to spans and metrics
+	Attribute = client.Attribute
+	// HedgePolicy configures hedged requests
+	HedgePolicy = client.HedgePolicy
+	// Tenant holds the attribution and credentials used for the calls of one tenant
+	Tenant = client.Tenant
+	// TenantRegistry maps tenant IDs to their configuration
+	TenantRegistry = client.TenantRegistry
+	// RequestSigner signs an outgoing request over its serialized body
+	RequestSigner = client.RequestSigner
+	// Config is a client configuration loaded from environment variables and profile files
+	Config = client.Config
+	// HARRecorder records the HTTP traffic of a client in the HAR format
+	HARRecorder = client.HARRecorder
+	// RequestOption configures a single API call
+	RequestOption = client.RequestOption
 )
 
 // Export helper functions
@@ -110,4 +486,142 @@ var (
 	SystemInputMessage = models.SystemInputMessage
 	// FunctionCallOutputMessage creates a new function call output message
 	FunctionCallOutputMessage = models.FunctionCallOutputMessage
+	// UnmarshalOutputItem decodes a single output item into the item type matching its type field
+	UnmarshalOutputItem = models.UnmarshalOutputItem
+	// UnmarshalInputItem decodes a single input item into the item type matching its type field
+	UnmarshalInputItem = models.UnmarshalInputItem
+	// JSONSchemaFormat creates a new strict JSON schema text format
+	JSONSchemaFormat = models.JSONSchemaFormat
+	// Float returns an Opt set to the given float
+	Float = models.Float
+	// Int returns an Opt set to the given int
+	Int = models.Int
+	// Bool returns an Opt set to the given bool
+	Bool = models.Bool
+	// NewInputMessage creates a new input message with the given role and content parts
+	NewInputMessage = models.NewInputMessage
+	// InputText creates a new text content part
+	InputText = models.InputText
+	// InputImageURL creates a new image content part from a URL or data URL
+	InputImageURL = models.InputImageURL
+	// InputImageData creates a new image content part from the image data
+	InputImageData = models.InputImageData
+	// InputImageFile creates a new image content part from an uploaded file
+	InputImageFile = models.InputImageFile
+	// InputFile creates a new file content part from an uploaded file
+	InputFile = models.InputFile
+	// InputFileURL creates a new file content part from a URL
+	InputFileURL = models.InputFileURL
+	// InputFileData creates a new file content part from the file data
+	InputFileData = models.InputFileData
+	// FunctionCallInput creates a new function call item, e.g. to replay a call made by the model
+	FunctionCallInput = models.FunctionCallInput
+	// FunctionCallOutput creates a new function call output item
+	FunctionCallOutput = models.FunctionCallOutput
+	// NewItemReference creates a new reference to an item of a previous response
+	NewItemReference = models.NewItemReference
+	// DefaultRetryPolicy returns a retry policy with sensible defaults for the OpenAI API
+	DefaultRetryPolicy = client.DefaultRetryPolicy
+	// NewRateLimiter creates a rate limiter allowing the given number of requests and tokens per minute
+	NewRateLimiter = client.NewRateLimiter
+	// NewConcurrencyLimiter creates a limiter allowing at most the given number of concurrent calls
+	NewConcurrencyLimiter = client.NewConcurrencyLimiter
+	// ContextWithPriority returns a context that carries the priority for calls made with it
+	ContextWithPriority = client.ContextWithPriority
+	// NewTenantRegistry creates a registry holding the given tenants
+	NewTenantRegistry = client.NewTenantRegistry
+	// ContextWithTenant returns a context that selects the tenant for calls made with it
+	ContextWithTenant = client.ContextWithTenant
+	// LoadCertPool returns the system certificate pool extended with the certificates in the given PEM files
+	LoadCertPool = client.LoadCertPool
+	// LoadConfig loads a client configuration from a profile file and environment variables
+	LoadConfig = client.LoadConfig
+	// NewHARRecorder creates an empty HAR recorder
+	NewHARRecorder = client.NewHARRecorder
+	// IsRetryable reports whether the operation that returned err may succeed when retried
+	IsRetryable = client.IsRetryable
+	// DefaultStreamTimeouts returns the default stream timeouts
+	DefaultStreamTimeouts = client.DefaultStreamTimeouts
+	// DefaultCircuitBreakerConfig returns the default circuit breaker configuration
+	DefaultCircuitBreakerConfig = client.DefaultCircuitBreakerConfig
+	// DefaultLogConfig returns the default log configuration
+	DefaultLogConfig = client.DefaultLogConfig
+	// StaticCredential returns a credential provider that always returns the given key
+	StaticCredential = client.StaticCredential
+	// EnvCredential returns a credential provider that reads the key from an environment variable
+	EnvCredential = client.EnvCredential
+	// NewFileCredential creates a credential provider backed by a file
+	NewFileCredential = client.NewFileCredential
+	// NewRefreshingCredential creates a credential provider that refreshes short-lived tokens
+	NewRefreshingCredential = client.NewRefreshingCredential
+	// NewCredentialPool creates a credential pool for the given keys
+	NewCredentialPool = client.NewCredentialPool
+)
+
+// Export providers
+const (
+	// ProviderOpenAI targets the OpenAI API or a gateway in front of it
+	ProviderOpenAI = client.ProviderOpenAI
+	// ProviderAzure targets an Azure OpenAI resource
+	ProviderAzure = client.ProviderAzure
+	// ProviderLocal targets a local OpenAI compatible server that needs no API key
+	ProviderLocal = client.ProviderLocal
+)
+
+// Export priorities
+const (
+	// PriorityLow is meant for batch and background work
+	PriorityLow = client.PriorityLow
+	// PriorityNormal is the priority of calls without an explicit priority
+	PriorityNormal = client.PriorityNormal
+	// PriorityHigh is meant for interactive, user-facing traffic
+	PriorityHigh = client.PriorityHigh
+)
+
+// Export reasoning settings
+const (
+	// ReasoningEffortMinimal is the minimal reasoning effort
+	ReasoningEffortMinimal = models.ReasoningEffortMinimal
+	// ReasoningEffortLow is the low reasoning effort
+	ReasoningEffortLow = models.ReasoningEffortLow
+	// ReasoningEffortMedium is the medium reasoning effort
+	ReasoningEffortMedium = models.ReasoningEffortMedium
+	// ReasoningEffortHigh is the high reasoning effort
+	ReasoningEffortHigh = models.ReasoningEffortHigh
+	// ReasoningSummaryAuto requests the most detailed reasoning summary available
+	ReasoningSummaryAuto = models.ReasoningSummaryAuto
+	// ReasoningSummaryConcise requests a concise reasoning summary
+	ReasoningSummaryConcise = models.ReasoningSummaryConcise
+	// ReasoningSummaryDetailed requests a detailed reasoning summary
+	ReasoningSummaryDetailed = models.ReasoningSummaryDetailed
+	// IncludeReasoningEncryptedContent includes the encrypted content of reasoning items in the output
+	IncludeReasoningEncryptedContent = models.IncludeReasoningEncryptedContent
+)
+
+// Export sentinel errors
+var (
+	// ErrAuthentication is matched by authentication errors
+	ErrAuthentication = client.ErrAuthentication
+	// ErrPermissionDenied is matched by permission errors
+	ErrPermissionDenied = client.ErrPermissionDenied
+	// ErrNotFound is matched by not found errors
+	ErrNotFound = client.ErrNotFound
+	// ErrRateLimit is matched by rate limit errors
+	ErrRateLimit = client.ErrRateLimit
+	// ErrContextLengthExceeded is matched by context length errors
+	ErrContextLengthExceeded = client.ErrContextLengthExceeded
+	// ErrServer is matched by server errors
+	ErrServer = client.ErrServer
+	// ErrTimeout is matched by timeout errors
+	ErrTimeout = client.ErrTimeout
+	// ErrStreamInterrupted is matched by interrupted stream errors
+	ErrStreamInterrupted = client.ErrStreamInterrupted
+	// ErrNoCredential is returned when a credential provider has no credential available
+	ErrNoCredential = client.ErrNoCredential
+	// ErrUnknownTenant is returned when a call selects a tenant that is not registered
+	ErrUnknownTenant = client.ErrUnknownTenant
+	// ErrClientClosed is matched by errors caused by the client being shut down
+	ErrClientClosed = client.ErrClientClosed
+	// ErrRefusal is matched by errors caused by the model refusing to produce structured output
+	ErrRefusal = client.ErrRefusal
 )
\ No newline at end of file